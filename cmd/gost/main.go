@@ -1,108 +1,512 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
-	// "github.com/fatih/color"
+	"github.com/fatih/color"
 	
 	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/i18n"
 	"github.com/Lingbou/go-search-tools/internal/search"
+	"github.com/Lingbou/go-search-tools/internal/tui"
+	"github.com/Lingbou/go-search-tools/internal/utils"
+)
+
+// 退出码，与 grep 保持一致
+const (
+	exitMatch   = 0 // 找到了结果
+	exitNoMatch = 1 // 没有找到结果
+	exitError   = 2 // 参数错误或搜索出错
 )
 
 var (
+	// 界面语言，必须最先初始化，之后创建的命令和参数说明才会使用对应的语言
+	language = setupLanguage(os.Args[1:])
+	
 	// 配置对象
 	cfg = config.NewDefaultConfig()
 	
+	// --context 参数的值，同时作用于前后上下文
+	contextLines int
+	
+	// --format-file 参数的值
+	formatFile string
+	
+	// 交互式界面初始的搜索方式
+	tuiMode string
+	
+	// -e 和 -f 参数的值
+	patternFlags []string
+	patternFiles []string
+	
+	// 从 -e、-f 和位置参数中收集的搜索模式
+	patterns []string
+	
+	// --query 指定的布尔查询
+	queryText string
+	
+	// 关闭分页程序，未启用分页时为空操作
+	stopPager = func() {}
+	
 	// 根命令
 	rootCmd = &cobra.Command{
 		Use:   "gost [command]",
-		Short: "文件搜索工具，支持按文件名和内容搜索",
-		Long:  "gost 是一个强大的文件搜索工具，可以快速在目录树中查找文件或内容",
+		Short: i18n.T("cmd.root.short"),
+		Long:  i18n.T("cmd.root.long"),
+		PersistentPreRunE: validateFlags,
 	}
 
 	// 搜索文件名的命令
 	searchNameCmd = &cobra.Command{
 		Use:   "name [flags] <pattern>",
-		Short: "按文件名搜索",
-		Long:  "按文件名搜索，支持通配符(*和?)",
+		Short: i18n.T("cmd.name.short"),
+		Long:  i18n.T("cmd.name.long"),
 		Args:  cobra.ExactArgs(1),
 		Run:   runSearchName,
 	}
 
 	// 搜索文件内容的命令
 	searchContentCmd = &cobra.Command{
-		Use:   "content [flags] <pattern>",
-		Short: "按文件内容搜索",
-		Long:  "按文件内容搜索，使用字符串匹配",
-		Args:  cobra.ExactArgs(1),
-		Run:   runSearchContent,
+		Use:     "content [flags] <pattern> | -e <pattern>... | -f <file> | --query <query>",
+		Short:   i18n.T("cmd.content.short"),
+		Long:    i18n.T("cmd.content.long"),
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: loadPatterns,
+		Run:     runSearchContent,
 	}
 
 	// 正则表达式搜索命令
 	searchRegexCmd = &cobra.Command{
-		Use:   "regex [flags] <pattern>",
-		Short: "使用正则表达式搜索文件内容",
-		Long:  "使用正则表达式搜索文件内容，支持完整的正则表达式语法",
-		Args:  cobra.ExactArgs(1),
+		Use:     "regex [flags] <pattern> | -e <pattern>... | -f <file> | --query <query>",
+		Short:   i18n.T("cmd.regex.short"),
+		Long:    i18n.T("cmd.regex.long"),
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: loadPatterns,
 		Run: func(cmd *cobra.Command, args []string) {
-			pattern := args[0]
+			applyContextFlags(cmd)
+			applyInvertFlags()
+			cfg.ReplaceEnabled = cmd.Flags().Changed("replace")
 			
 			// 创建正则表达式搜索器
-			searcher := search.NewRegexSearcher(cfg, pattern)
+			var searcher *search.Searcher
+			var err error
+			if queryText != "" {
+				if searcher, err = search.NewQuerySearcher(cfg, queryText, true); err != nil {
+					utils.PrintError(i18n.T("error.invalid_query"), err)
+					exit(exitError)
+				}
+			} else if searcher, err = search.NewRegexSearcher(cfg, patterns); err != nil {
+				utils.PrintError(i18n.T("error.invalid_regex"), err)
+				exit(exitError)
+			}
 			
 			// 执行搜索
-			if err := searcher.Search(); err != nil {
-				os.Exit(1)
-			}
+			exit(exitStatus(searcher.Search()))
 		},
 	}
+	
+	// 交互式搜索界面
+	tuiCmd = &cobra.Command{
+		Use:     "tui [flags] [pattern]",
+		Short:   i18n.T("cmd.tui.short"),
+		Long:    i18n.T("cmd.tui.long"),
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: validateTUIFlags,
+		Run:     runTUI,
+	}
 )
 
 func init() {
 	// 全局参数
-	rootCmd.PersistentFlags().StringVarP(&cfg.SearchPath, "path", "p", ".", "搜索路径")
-	rootCmd.PersistentFlags().BoolVarP(&cfg.IgnoreCase, "ignore-case", "i", false, "忽略大小写")
-	rootCmd.PersistentFlags().BoolVarP(&cfg.ColorOutput, "color", "c", true, "启用颜色输出")
-	rootCmd.PersistentFlags().BoolVarP(&cfg.ShowProgress, "progress", "P", false, "显示进度")
+	rootCmd.PersistentFlags().StringVarP(&cfg.SearchPath, "path", "p", ".", i18n.T("flag.path"))
+	rootCmd.PersistentFlags().BoolVarP(&cfg.IgnoreCase, "ignore-case", "i", false, i18n.T("flag.ignore-case"))
+	rootCmd.PersistentFlags().StringVarP(&cfg.Color, "color", "c", config.ColorAuto, i18n.T("flag.color"))
+	rootCmd.PersistentFlags().Lookup("color").NoOptDefVal = config.ColorAlways
+	rootCmd.PersistentFlags().BoolVar(&cfg.Pager, "pager", false, i18n.T("flag.pager"))
+	rootCmd.PersistentFlags().StringVarP(&cfg.Progress, "progress", "P", "", i18n.T("flag.progress"))
+	rootCmd.PersistentFlags().Lookup("progress").NoOptDefVal = config.ProgressBar
+	rootCmd.PersistentFlags().IntVar(&cfg.ProgressFD, "progress-fd", 2, i18n.T("flag.progress-fd"))
+	rootCmd.PersistentFlags().BoolVar(&cfg.ShowStats, "stats", false, i18n.T("flag.stats"))
+	rootCmd.PersistentFlags().StringVar(&language, "lang", language, i18n.T("flag.lang"))
+	rootCmd.PersistentFlags().BoolVarP(&cfg.Quiet, "quiet", "q", false, i18n.T("flag.quiet"))
+	rootCmd.PersistentFlags().BoolVarP(&cfg.NoMessages, "no-messages", "s", false, i18n.T("flag.no-messages"))
+	rootCmd.PersistentFlags().BoolVar(&cfg.JSONOutput, "json", false, i18n.T("flag.json"))
+	rootCmd.PersistentFlags().BoolVar(&cfg.Vimgrep, "vimgrep", false, i18n.T("flag.vimgrep"))
+	rootCmd.PersistentFlags().StringVar(&cfg.OutputFormat, "output-format", config.OutputFormatText, i18n.T("flag.output-format"))
+	rootCmd.PersistentFlags().StringVar(&cfg.RuleID, "rule-id", "", i18n.T("flag.rule-id"))
+	rootCmd.PersistentFlags().StringVar(&cfg.PathStyle, "path-style", config.PathStyleRelative, i18n.T("flag.path-style"))
+	rootCmd.PersistentFlags().BoolVarP(&cfg.NullSeparator, "null", "0", false, i18n.T("flag.null"))
+	rootCmd.PersistentFlags().BoolVar(&cfg.Hyperlink, "hyperlink", false, i18n.T("flag.hyperlink"))
+	rootCmd.PersistentFlags().StringVar(&cfg.HyperlinkFormat, "hyperlink-format", config.DefaultHyperlinkFormat, i18n.T("flag.hyperlink-format"))
+	rootCmd.PersistentFlags().StringVar(&cfg.ReportPath, "report", "", i18n.T("flag.report"))
+	rootCmd.PersistentFlags().IntVar(&cfg.ReportContext, "report-context", 2, i18n.T("flag.report-context"))
+	rootCmd.PersistentFlags().StringVar(&cfg.Format, "format", "", i18n.T("flag.format"))
+	rootCmd.PersistentFlags().StringVar(&formatFile, "format-file", "", i18n.T("flag.format-file"))
+	rootCmd.PersistentFlags().StringVar(&cfg.SortBy, "sort", config.SortWalk, i18n.T("flag.sort"))
+	rootCmd.PersistentFlags().BoolVar(&cfg.SortReverse, "sort-reverse", false, i18n.T("flag.sort-reverse"))
+	
+	// 文件名搜索输出参数
+	searchNameCmd.Flags().BoolVar(&cfg.ShowMetadata, "metadata", true, i18n.T("flag.metadata"))
+	searchNameCmd.Flags().Bool("csv", false, i18n.T("flag.csv"))
+	searchNameCmd.Flags().Bool("tsv", false, i18n.T("flag.tsv"))
+	searchNameCmd.Flags().StringVar(&cfg.Columns, "columns", config.DefaultCSVColumns, i18n.T("flag.columns"))
 	
 	// 文件名搜索参数
-	searchNameCmd.Flags().BoolVarP(&cfg.Recursive, "recursive", "r", true, "递归搜索子目录")
-	searchNameCmd.Flags().IntVarP(&cfg.MaxDepth, "max-depth", "d", -1, "最大递归深度，-1表示不限制")
-	searchNameCmd.Flags().StringSliceVarP(&cfg.ExcludeDirs, "exclude-dir", "e", []string{}, "排除的目录")
-	searchNameCmd.Flags().StringSliceVarP(&cfg.IncludeExts, "include-ext", "I", []string{}, "只包含的文件扩展名")
-	searchNameCmd.Flags().StringSliceVarP(&cfg.ExcludeExts, "exclude-ext", "E", []string{}, "排除的文件扩展名")
+	searchNameCmd.Flags().BoolVarP(&cfg.Recursive, "recursive", "r", true, i18n.T("flag.recursive"))
+	searchNameCmd.Flags().IntVarP(&cfg.MaxDepth, "max-depth", "d", -1, i18n.T("flag.max-depth"))
+	searchNameCmd.Flags().StringSliceVarP(&cfg.ExcludeDirs, "exclude-dir", "e", []string{}, i18n.T("flag.exclude-dir"))
+	searchNameCmd.Flags().StringSliceVarP(&cfg.IncludeExts, "include-ext", "I", []string{}, i18n.T("flag.include-ext"))
+	searchNameCmd.Flags().StringSliceVarP(&cfg.ExcludeExts, "exclude-ext", "E", []string{}, i18n.T("flag.exclude-ext"))
 	
 	// 内容搜索参数
-	searchContentCmd.Flags().BoolVarP(&cfg.Recursive, "recursive", "r", true, "递归搜索子目录")
-	searchContentCmd.Flags().IntVarP(&cfg.MaxDepth, "max-depth", "d", -1, "最大递归深度，-1表示不限制")
-	searchContentCmd.Flags().StringSliceVarP(&cfg.ExcludeDirs, "exclude-dir", "e", []string{}, "排除的目录")
-	searchContentCmd.Flags().StringSliceVarP(&cfg.IncludeExts, "include-ext", "I", []string{}, "只包含的文件扩展名")
-	searchContentCmd.Flags().StringSliceVarP(&cfg.ExcludeExts, "exclude-ext", "E", []string{}, "排除的文件扩展名")
-	searchContentCmd.Flags().IntVarP(&cfg.NumWorkers, "workers", "w", 4, "并行工作线程数")
-	searchContentCmd.Flags().DurationVarP(&cfg.Timeout, "timeout", "t", 0, "搜索超时时间，例如10s, 2m等")
+	searchContentCmd.Flags().BoolVarP(&cfg.Recursive, "recursive", "r", true, i18n.T("flag.recursive"))
+	searchContentCmd.Flags().IntVarP(&cfg.MaxDepth, "max-depth", "d", -1, i18n.T("flag.max-depth"))
+	searchContentCmd.Flags().StringSliceVarP(&cfg.ExcludeDirs, "exclude-dir", "X", []string{}, i18n.T("flag.exclude-dir"))
+	searchContentCmd.Flags().StringSliceVarP(&cfg.IncludeExts, "include-ext", "I", []string{}, i18n.T("flag.include-ext"))
+	searchContentCmd.Flags().StringSliceVarP(&cfg.ExcludeExts, "exclude-ext", "E", []string{}, i18n.T("flag.exclude-ext"))
+	searchContentCmd.Flags().IntVarP(&cfg.NumWorkers, "workers", "j", 4, i18n.T("flag.workers"))
+	searchContentCmd.Flags().DurationVarP(&cfg.Timeout, "timeout", "t", 0, i18n.T("flag.timeout"))
+	addPatternFlags(searchContentCmd)
+	addMatchFlags(searchContentCmd)
+	addContextFlags(searchContentCmd)
+	addResultModeFlags(searchContentCmd)
+	addHeadingFlags(searchContentCmd)
 	
 	// 正则表达式搜索参数
-	searchRegexCmd.Flags().BoolVarP(&cfg.Recursive, "recursive", "r", true, "递归搜索子目录")
-	searchRegexCmd.Flags().IntVarP(&cfg.MaxDepth, "max-depth", "d", -1, "最大递归深度，-1表示不限制")
-	searchRegexCmd.Flags().StringSliceVarP(&cfg.ExcludeDirs, "exclude-dir", "e", []string{}, "排除的目录")
-	searchRegexCmd.Flags().StringSliceVarP(&cfg.IncludeExts, "include-ext", "I", []string{}, "只包含的文件扩展名")
-	searchRegexCmd.Flags().StringSliceVarP(&cfg.ExcludeExts, "exclude-ext", "E", []string{}, "排除的文件扩展名")
-	searchRegexCmd.Flags().IntVarP(&cfg.NumWorkers, "workers", "w", 4, "并行工作线程数")
-	searchRegexCmd.Flags().DurationVarP(&cfg.Timeout, "timeout", "t", 0, "搜索超时时间，例如10s, 2m等")
+	searchRegexCmd.Flags().BoolVarP(&cfg.Recursive, "recursive", "r", true, i18n.T("flag.recursive"))
+	searchRegexCmd.Flags().IntVarP(&cfg.MaxDepth, "max-depth", "d", -1, i18n.T("flag.max-depth"))
+	searchRegexCmd.Flags().StringSliceVarP(&cfg.ExcludeDirs, "exclude-dir", "X", []string{}, i18n.T("flag.exclude-dir"))
+	searchRegexCmd.Flags().StringSliceVarP(&cfg.IncludeExts, "include-ext", "I", []string{}, i18n.T("flag.include-ext"))
+	searchRegexCmd.Flags().StringSliceVarP(&cfg.ExcludeExts, "exclude-ext", "E", []string{}, i18n.T("flag.exclude-ext"))
+	searchRegexCmd.Flags().IntVarP(&cfg.NumWorkers, "workers", "j", 4, i18n.T("flag.workers"))
+	searchRegexCmd.Flags().DurationVarP(&cfg.Timeout, "timeout", "t", 0, i18n.T("flag.timeout"))
+	addPatternFlags(searchRegexCmd)
+	addMatchFlags(searchRegexCmd)
+	addContextFlags(searchRegexCmd)
+	addResultModeFlags(searchRegexCmd)
+	addHeadingFlags(searchRegexCmd)
+	searchRegexCmd.Flags().BoolVarP(&cfg.OnlyMatching, "only-matching", "o", false, i18n.T("flag.only-matching"))
+	searchRegexCmd.Flags().StringVar(&cfg.Replace, "replace", "", i18n.T("flag.replace"))
+	
+	// 交互式界面参数，过滤条件在界面中可以按 Ctrl-F 临时关闭
+	tuiCmd.Flags().StringVarP(&tuiMode, "mode", "m", tui.ModeContent, i18n.T("flag.mode"))
+	tuiCmd.Flags().IntVarP(&cfg.MaxDepth, "max-depth", "d", -1, i18n.T("flag.max-depth"))
+	tuiCmd.Flags().StringSliceVarP(&cfg.ExcludeDirs, "exclude-dir", "e", []string{}, i18n.T("flag.exclude-dir"))
+	tuiCmd.Flags().StringSliceVarP(&cfg.IncludeExts, "include-ext", "I", []string{}, i18n.T("flag.include-ext"))
+	tuiCmd.Flags().StringSliceVarP(&cfg.ExcludeExts, "exclude-ext", "E", []string{}, i18n.T("flag.exclude-ext"))
+	tuiCmd.Flags().IntVarP(&cfg.NumWorkers, "workers", "j", 4, i18n.T("flag.workers"))
+	tuiCmd.Flags().DurationVarP(&cfg.Timeout, "timeout", "t", 0, i18n.T("flag.timeout"))
 	
 	// 将子命令添加到根命令
-	rootCmd.AddCommand(searchNameCmd, searchContentCmd, searchRegexCmd)
+	rootCmd.AddCommand(searchNameCmd, searchContentCmd, searchRegexCmd, tuiCmd)
+}
+
+// 检查参数取值是否合法
+func validateFlags(cmd *cobra.Command, args []string) error {
+	if err := i18n.SetLanguage(language); err != nil {
+		return err
+	}
+	
+	switch cfg.PathStyle {
+	case config.PathStyleRelative, config.PathStyleAbsolute, config.PathStyleBasename:
+	default:
+		return fmt.Errorf(i18n.T("error.invalid_path_style"), cfg.PathStyle)
+	}
+	
+	switch cfg.SortBy {
+	case config.SortWalk, config.SortNone, config.SortPath, config.SortSize, config.SortModTime:
+	case config.SortMatches:
+		if cmd == searchNameCmd {
+			return errors.New(i18n.T("error.sort_matches_name"))
+		}
+	default:
+		return fmt.Errorf(i18n.T("error.invalid_sort"), cfg.SortBy)
+	}
+	if cfg.SortReverse && (cfg.SortBy == config.SortWalk || cfg.SortBy == config.SortNone) {
+		return errors.New(i18n.T("error.sort_reverse"))
+	}
+	
+	// --quiet 找到第一个结果就结束搜索，无法生成完整的报告
+	if cfg.Quiet && cfg.ReportPath != "" {
+		return errors.New(i18n.T("error.quiet_report"))
+	}
+	
+	// 反向匹配的行中没有匹配的文本
+	if cfg.InvertMatch && cfg.OnlyMatching {
+		return errors.New(i18n.T("error.invert_only_matching"))
+	}
+	
+	// 从文件加载输出模板
+	if formatFile != "" {
+		if cfg.Format != "" {
+			return errors.New(i18n.T("error.format_conflict"))
+		}
+		content, err := os.ReadFile(formatFile)
+		if err != nil {
+			return fmt.Errorf(i18n.T("error.read_format_file"), err)
+		}
+		cfg.Format = strings.TrimSuffix(string(content), "\n")
+	}
+	
+	// --json 和 --vimgrep 分别等同于对应的 --output-format
+	for _, alias := range []struct {
+		flag   string
+		format string
+	}{
+		{"json", config.OutputFormatJSON},
+		{"vimgrep", config.OutputFormatVimgrep},
+		{"csv", config.OutputFormatCSV},
+		{"tsv", config.OutputFormatTSV},
+	} {
+		flag, format := alias.flag, alias.format
+		if enabled, _ := cmd.Flags().GetBool(flag); !enabled {
+			continue
+		}
+		if (cmd.Flags().Changed("output-format") || cfg.OutputFormat != config.OutputFormatText) && cfg.OutputFormat != format {
+			return fmt.Errorf(i18n.T("error.format_alias_conflict"), flag, cfg.OutputFormat)
+		}
+		cfg.OutputFormat = format
+	}
+	switch cfg.OutputFormat {
+	case config.OutputFormatText, config.OutputFormatJSON, config.OutputFormatSARIF, config.OutputFormatVimgrep:
+	case config.OutputFormatCSV, config.OutputFormatTSV:
+		if cmd != searchNameCmd {
+			return fmt.Errorf(i18n.T("error.format_name_only"), cfg.OutputFormat)
+		}
+	default:
+		return fmt.Errorf(i18n.T("error.invalid_output_format"), cfg.OutputFormat)
+	}
+	if cfg.Format != "" && cfg.OutputFormat != config.OutputFormatText {
+		return fmt.Errorf(i18n.T("error.template_output_format"), cfg.OutputFormat)
+	}
+	
+	// 为兼容旧版本，--progress=true 和 --progress=false 分别等同于 bar 和不显示进度
+	switch cfg.Progress {
+	case "", "false":
+		cfg.Progress = ""
+	case config.ProgressBar, "true":
+		cfg.Progress = config.ProgressBar
+	case config.ProgressJSON:
+		if cfg.ProgressFD < 0 {
+			return fmt.Errorf(i18n.T("error.invalid_progress_fd"), cfg.ProgressFD)
+		}
+		if err := utils.CheckProgressOutput(cfg.ProgressFD); err != nil {
+			return fmt.Errorf(i18n.T("error.progress_fd_closed"), cfg.ProgressFD, err)
+		}
+	default:
+		return fmt.Errorf(i18n.T("error.invalid_progress"), cfg.Progress)
+	}
+	cfg.ShowProgress = cfg.Progress != ""
+	
+	if err := applyColorFlags(); err != nil {
+		return err
+	}
+	
+	// 未指定 --heading 或 --no-heading 时，只在输出到终端时按文件分组
+	// 输出到管道时保持每行一个结果，便于其他程序处理
+	if cmd.Flags().Lookup("heading") != nil && !cmd.Flags().Changed("heading") && !cmd.Flags().Changed("no-heading") {
+		cfg.Heading = utils.IsTerminal(os.Stdout) && !cfg.NullSeparator
+	}
+	
+	// 指定超链接格式时自动启用超链接
+	if cmd.Flags().Changed("hyperlink-format") {
+		cfg.Hyperlink = true
+	}
+	
+	// 颜色和分组方式已经根据终端确定，最后再将输出重定向到分页程序
+	if cfg.Pager && !cfg.Quiet && cmd != tuiCmd && utils.IsTerminal(os.Stdout) {
+		stop, err := utils.StartPager()
+		if err != nil {
+			return fmt.Errorf(i18n.T("error.start_pager"), err)
+		}
+		stopPager = stop
+	}
+	return nil
+}
+
+// 根据 --lang 参数或 LC_ALL、LC_MESSAGES、LANG 环境变量设置界面语言
+// cobra 解析参数时命令和参数的说明已经生成，因此需要提前从参数中查找 --lang
+func setupLanguage(args []string) string {
+	lang := i18n.Detect()
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if value, ok := strings.CutPrefix(arg, "--lang="); ok {
+			lang = value
+		} else if arg == "--lang" && i+1 < len(args) {
+			lang = args[i+1]
+		}
+	}
+	
+	// 不支持的语言在 validateFlags 中报错，这里先使用默认语言
+	if i18n.SetLanguage(lang) != nil {
+		return lang
+	}
+	return i18n.Language()
+}
+
+// 根据 --color 和 NO_COLOR 环境变量决定是否输出颜色
+// 为兼容旧版本，--color=true 和 --color=false 分别等同于 always 和 never
+func applyColorFlags() error {
+	switch cfg.Color {
+	case config.ColorAlways, "true":
+		cfg.ColorOutput = true
+	case config.ColorNever, "false":
+		cfg.ColorOutput = false
+	case config.ColorAuto:
+		// 参见 https://no-color.org，NO_COLOR 非空时不输出颜色
+		cfg.ColorOutput = utils.IsTerminal(os.Stdout) && os.Getenv("NO_COLOR") == ""
+	default:
+		return fmt.Errorf(i18n.T("error.invalid_color"), cfg.Color)
+	}
+	
+	// 摘要等信息直接使用 color 包输出，同样遵循该设置
+	color.NoColor = !cfg.ColorOutput
+	return nil
+}
+
+// 添加指定多个搜索模式的参数
+func addPatternFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&patternFlags, "pattern", "e", nil, i18n.T("flag.pattern"))
+	cmd.Flags().StringArrayVarP(&patternFiles, "pattern-file", "f", nil, i18n.T("flag.pattern-file"))
+	cmd.Flags().StringVar(&queryText, "query", "", i18n.T("flag.query"))
+	cmd.Flags().BoolVar(&cfg.QueryPerLine, "query-per-line", false, i18n.T("flag.query-per-line"))
+}
+
+// 添加整词、整行和反向匹配参数
+func addMatchFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&cfg.WordMatch, "word-regexp", "w", false, i18n.T("flag.word-regexp"))
+	cmd.Flags().BoolVarP(&cfg.LineMatch, "line-regexp", "x", false, i18n.T("flag.line-regexp"))
+	cmd.Flags().BoolVarP(&cfg.InvertMatch, "invert-match", "v", false, i18n.T("flag.invert-match"))
+}
+
+// 反向匹配时，文件列表模式按文件取反：-l 输出没有任何一行匹配的文件，-L 输出有匹配的文件
+func applyInvertFlags() {
+	if cfg.InvertMatch {
+		cfg.FilesWithMatches, cfg.FilesWithoutMatch = cfg.FilesWithoutMatch, cfg.FilesWithMatches
+	}
+}
+
+// 从 -e、-f 和位置参数中收集搜索模式，至少需要一个模式
+// 使用 --query 时搜索条件都写在查询中，不能再指定其他模式
+func loadPatterns(cmd *cobra.Command, args []string) error {
+	if queryText != "" {
+		if len(args) > 0 || len(patternFlags) > 0 || len(patternFiles) > 0 {
+			return errors.New(i18n.T("error.query_with_patterns"))
+		}
+		return nil
+	}
+	if cfg.QueryPerLine {
+		return errors.New(i18n.T("error.query_per_line"))
+	}
+	
+	patterns = append([]string{}, patternFlags...)
+	for _, file := range patternFiles {
+		filePatterns, err := readPatternFile(file)
+		if err != nil {
+			return fmt.Errorf(i18n.T("error.read_pattern_file"), err)
+		}
+		patterns = append(patterns, filePatterns...)
+	}
+	patterns = append(patterns, args...)
+	
+	if len(patterns) == 0 {
+		return errors.New(i18n.T("error.no_patterns"))
+	}
+	return nil
+}
+
+// 读取模式文件，每行一个模式，忽略空行，文件名为 - 时从标准输入读取
+func readPatternFile(name string) ([]string, error) {
+	var content []byte
+	var err error
+	if name == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	var filePatterns []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if line = strings.TrimSuffix(line, "\r"); line != "" {
+			filePatterns = append(filePatterns, line)
+		}
+	}
+	return filePatterns, nil
+}
+
+// 添加上下文相关参数
+func addContextFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVarP(&cfg.AfterContext, "after-context", "A", 0, i18n.T("flag.after-context"))
+	cmd.Flags().IntVarP(&cfg.BeforeContext, "before-context", "B", 0, i18n.T("flag.before-context"))
+	cmd.Flags().IntVarP(&contextLines, "context", "C", 0, i18n.T("flag.context"))
+}
+
+// 添加计数和文件列表模式参数
+func addResultModeFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&cfg.CountLines, "count", false, i18n.T("flag.count"))
+	cmd.Flags().BoolVar(&cfg.CountMatches, "count-matches", false, i18n.T("flag.count-matches"))
+	cmd.Flags().BoolVarP(&cfg.FilesWithMatches, "files-with-matches", "l", false, i18n.T("flag.files-with-matches"))
+	cmd.Flags().BoolVarP(&cfg.FilesWithoutMatch, "files-without-match", "L", false, i18n.T("flag.files-without-match"))
+	cmd.MarkFlagsMutuallyExclusive("count", "count-matches", "files-with-matches", "files-without-match")
+}
+
+// 添加按文件分组输出参数
+func addHeadingFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&cfg.Heading, "heading", false, i18n.T("flag.heading"))
+	cmd.Flags().Bool("no-heading", false, i18n.T("flag.no-heading"))
+	cmd.MarkFlagsMutuallyExclusive("heading", "no-heading")
+}
+
+// 根据 --context 设置未单独指定的前后上下文行数
+func applyContextFlags(cmd *cobra.Command) {
+	if !cmd.Flags().Changed("context") {
+		return
+	}
+	if !cmd.Flags().Changed("after-context") {
+		cfg.AfterContext = contextLines
+	}
+	if !cmd.Flags().Changed("before-context") {
+		cfg.BeforeContext = contextLines
+	}
 }
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		fmt.Fprintln(os.Stderr, err)
+		exit(exitError)
+	}
+	stopPager()
+}
+
+// 根据搜索结果确定退出码
+// 使用 --quiet 且已经找到结果时，即使有文件出错也返回 exitMatch
+func exitStatus(found bool, err error) int {
+	switch {
+	case err != nil && !(cfg.Quiet && found):
+		return exitError
+	case found:
+		return exitMatch
+	default:
+		return exitNoMatch
 	}
 }
 
+// 等待分页程序退出后再结束进程，否则分页程序会失去终端
+func exit(code int) {
+	stopPager()
+	os.Exit(code)
+}
+
 // 按文件名搜索的执行函数
 func runSearchName(cmd *cobra.Command, args []string) {
 	pattern := args[0]
@@ -111,20 +515,78 @@ func runSearchName(cmd *cobra.Command, args []string) {
 	searcher := search.NewNameSearcher(cfg)
 	
 	// 执行搜索
-	if err := searcher.Search(pattern); err != nil {
-		os.Exit(1)
-	}
+	exit(exitStatus(searcher.Search(pattern)))
 }
 
 // 按内容搜索的执行函数
 func runSearchContent(cmd *cobra.Command, args []string) {
-	pattern := args[0]
+	applyContextFlags(cmd)
+	applyInvertFlags()
 	
 	// 创建内容搜索器
-	searcher := search.NewContentSearcher(cfg, pattern)
+	var searcher *search.Searcher
+	if queryText != "" {
+		var err error
+		if searcher, err = search.NewQuerySearcher(cfg, queryText, false); err != nil {
+			utils.PrintError(i18n.T("error.invalid_query"), err)
+			exit(exitError)
+		}
+	} else {
+		searcher = search.NewContentSearcher(cfg, patterns)
+	}
 	
 	// 执行搜索
-	if err := searcher.Search(); err != nil {
-		os.Exit(1)
+	exit(exitStatus(searcher.Search()))
+}
+
+// 检查交互式界面的参数
+func validateTUIFlags(cmd *cobra.Command, args []string) error {
+	switch tuiMode {
+	case tui.ModeName, tui.ModeContent, tui.ModeRegex:
+	default:
+		return fmt.Errorf(i18n.T("error.invalid_mode"), tuiMode)
+	}
+	return nil
+}
+
+// 交互式界面的执行函数
+func runTUI(cmd *cobra.Command, args []string) {
+	query := ""
+	if len(args) > 0 {
+		query = args[0]
+	}
+	
+	// 检查路径是否存在，界面启动后无法再输出错误信息
+	if _, err := os.Stat(cfg.SearchPath); err != nil {
+		utils.PrintError(i18n.T("search.path_not_found"), cfg.SearchPath)
+		exit(exitError)
 	}
-}
\ No newline at end of file
+	
+	// 界面总是显示在终端上，--color=auto 时不受标准输出是否被重定向的影响
+	useColor := cfg.ColorOutput || (cfg.Color == config.ColorAuto && os.Getenv("NO_COLOR") == "")
+	
+	sel, err := tui.Run(cfg, tui.Options{Mode: tuiMode, Query: query, Color: useColor})
+	if err != nil {
+		utils.PrintError(i18n.T("error.tui"), err)
+		exit(exitError)
+	}
+	
+	switch sel.Action {
+	case tui.ActionPrint:
+		// 文件名搜索只输出路径，便于 vim $(gost tui -m name) 这样的用法
+		// 内容搜索使用与 --vimgrep 相同的格式
+		if sel.Line > 0 {
+			fmt.Printf("%s:%d:%d:%s\n", sel.Path, sel.Line, sel.Column, sel.Text)
+		} else {
+			fmt.Println(sel.Path)
+		}
+	case tui.ActionEdit:
+		if err := tui.OpenEditor(sel); err != nil {
+			utils.PrintError(i18n.T("error.open_editor"), err)
+			exit(exitError)
+		}
+	default:
+		exit(exitNoMatch)
+	}
+	exit(exitMatch)
+}
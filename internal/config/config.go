@@ -4,13 +4,84 @@ import (
 	"time"
 )
 
+// 输出格式
+const (
+	OutputFormatText    = "text"    // 人类可读的文本
+	OutputFormatJSON    = "json"    // JSON Lines
+	OutputFormatSARIF   = "sarif"   // SARIF 2.1.0 日志
+	OutputFormatVimgrep = "vimgrep" // 每处匹配一行的 path:line:col:text
+	OutputFormatCSV     = "csv"     // 逗号分隔的文件列表，只用于文件名搜索
+	OutputFormatTSV     = "tsv"     // 制表符分隔的文件列表，只用于文件名搜索
+)
+
+// DefaultHyperlinkFormat 默认的超链接格式，使用 file:// URL
+const DefaultHyperlinkFormat = "file://{host}{path}"
+
+// DefaultCSVColumns CSV/TSV 输出默认的列
+const DefaultCSVColumns = "path,size,mtime"
+
+// 颜色输出模式
+const (
+	ColorAuto   = "auto"   // 输出到终端且未设置 NO_COLOR 时启用颜色
+	ColorAlways = "always" // 总是启用颜色
+	ColorNever  = "never"  // 不使用颜色
+)
+
+// 进度显示方式
+const (
+	ProgressBar  = "bar"  // 在终端显示进度条
+	ProgressJSON = "json" // 以 JSON Lines 格式输出进度事件
+)
+
+// 结果排序方式
+const (
+	SortWalk    = ""        // 按遍历顺序流式输出
+	SortNone    = "none"    // 按完成顺序输出，不保证稳定
+	SortPath    = "path"    // 按路径排序
+	SortSize    = "size"    // 按文件大小排序
+	SortModTime = "mtime"   // 按修改时间排序
+	SortMatches = "matches" // 按匹配行数排序
+)
+
+// 路径显示样式
+const (
+	PathStyleRelative = "relative" // 相对于搜索路径
+	PathStyleAbsolute = "absolute" // 绝对路径
+	PathStyleBasename = "basename" // 仅文件名
+)
+
 // SearchConfig 包含所有搜索相关的配置选项
 type SearchConfig struct {
 	// 通用选项
 	SearchPath   string
 	IgnoreCase   bool
-	ColorOutput  bool
+	ColorOutput  bool   // 是否输出颜色，由 Color 根据终端和 NO_COLOR 决定
+	Color        string // 颜色输出模式: auto, always, never
+	Pager        bool   // 输出到终端时使用 $PAGER 分页显示
 	ShowProgress bool
+	Progress     string // 进度显示方式: bar, json，不显示进度时为空
+	ProgressFD   int    // JSON 进度事件输出到的文件描述符
+	JSONOutput   bool
+	Vimgrep      bool
+	OutputFormat string
+	RuleID       string // SARIF 输出中的规则 ID，默认使用搜索模式
+
+	// 路径与元数据显示选项
+	PathStyle     string
+	NullSeparator bool
+	ShowMetadata  bool
+	Columns       string // CSV/TSV 输出的列，以逗号分隔
+
+	// 终端超链接选项
+	Hyperlink       bool
+	HyperlinkFormat string
+
+	// HTML 报告选项
+	ReportPath    string // 报告文件路径，为空时不生成报告
+	ReportContext int    // 报告中匹配片段的上下文行数
+
+	// 自定义输出模板，使用 text/template 语法
+	Format string
 
 	// 文件过滤选项
 	Recursive   bool
@@ -22,21 +93,84 @@ type SearchConfig struct {
 	// 内容搜索选项
 	NumWorkers int
 	Timeout    time.Duration
+
+	// 结果模式，最多只能启用其中一个
+	CountLines        bool // 只输出每个文件的匹配行数
+	CountMatches      bool // 只输出每个文件的匹配次数
+	FilesWithMatches  bool // 只输出包含匹配的文件
+	FilesWithoutMatch bool // 只输出不包含匹配的文件
+
+	// 正则表达式输出选项
+	OnlyMatching   bool   // 只输出匹配的文本
+	Replace        string // 替换模板
+	ReplaceEnabled bool   // 是否启用替换，允许替换为空字符串
+
+	// 按行而不是按文件判断 --query 查询是否成立
+	QueryPerLine bool
+
+	// 匹配边界，同时启用时以整行为准
+	WordMatch bool // 只匹配整个单词
+	LineMatch bool // 只匹配整行
+
+	// 输出不匹配的行；文件列表模式下按文件取反，参见 search.NewSearcher
+	InvertMatch bool
+
+	// 输出搜索过程的统计信息
+	ShowStats bool
+
+	// 不输出任何结果，找到第一个结果后立即结束，只通过退出码报告是否找到
+	Quiet bool
+
+	// 不输出单个文件的读取错误
+	NoMessages bool
+
+	// 按文件分组输出，文件路径作为标题只输出一次
+	Heading bool
+
+	// 排序选项
+	SortBy      string
+	SortReverse bool
+
+	// 上下文选项
+	BeforeContext int
+	AfterContext  int
 }
 
 // NewDefaultConfig 返回默认配置
 func NewDefaultConfig() *SearchConfig {
 	return &SearchConfig{
-		SearchPath:   ".",
-		IgnoreCase:   false,
-		ColorOutput:  true,
-		ShowProgress: false,
-		Recursive:    true,
-		MaxDepth:     -1,
-		ExcludeDirs:  []string{},
-		IncludeExts:  []string{},
-		ExcludeExts:  []string{},
-		NumWorkers:   4,
-		Timeout:      0,
+		SearchPath:      ".",
+		IgnoreCase:      false,
+		ColorOutput:     true,
+		Color:           ColorAuto,
+		ShowProgress:    false,
+		ProgressFD:      2,
+		JSONOutput:      false,
+		Vimgrep:         false,
+		Hyperlink:       false,
+		HyperlinkFormat: DefaultHyperlinkFormat,
+		ReportPath:      "",
+		ReportContext:   2,
+		OutputFormat:    OutputFormatText,
+		RuleID:          "",
+		PathStyle:       PathStyleRelative,
+		NullSeparator:   false,
+		ShowMetadata:    true,
+		Columns:         DefaultCSVColumns,
+		Recursive:       true,
+		MaxDepth:        -1,
+		ExcludeDirs:     []string{},
+		IncludeExts:     []string{},
+		ExcludeExts:     []string{},
+		NumWorkers:      4,
+		Timeout:         0,
+		BeforeContext:   0,
+		AfterContext:    0,
 	}
-}
\ No newline at end of file
+}
+
+// LineOutput 判断是否逐行输出匹配结果
+// 启用计数或文件列表模式时只输出文件级别的结果
+func (c *SearchConfig) LineOutput() bool {
+	return !c.CountLines && !c.CountMatches && !c.FilesWithMatches && !c.FilesWithoutMatch
+}
@@ -0,0 +1,197 @@
+package i18n
+
+// en 英文消息目录
+var en = map[string]string{
+	// 命令
+	"cmd.root.short":    "File search tool that searches by file name and content",
+	"cmd.root.long":     "gost is a powerful file search tool that quickly finds files or content in a directory tree",
+	"cmd.name.short":    "Search by file name",
+	"cmd.name.long":     "Search by file name, with wildcard support (* and ?)",
+	"cmd.content.short": "Search file content",
+	"cmd.content.long":  "Search file content using plain string matching",
+	"cmd.regex.short":   "Search file content with a regular expression",
+	"cmd.regex.long":    "Search file content with a regular expression, supporting the full regular expression syntax",
+	"cmd.tui.short":     "Interactive search interface",
+	"cmd.tui.long":      "Full-screen interactive search that updates results as you type and previews the file around the selected result. Press Enter to print the selection or Ctrl-O to open it in $EDITOR",
+
+	// 参数说明
+	"flag.path":                "path to search",
+	"flag.ignore-case":         "ignore case",
+	"flag.color":               "color output: auto (when writing to a terminal), always, never",
+	"flag.pager":               "page output through $PAGER (less by default) when writing to a terminal",
+	"flag.progress":            "show progress: bar (progress bar, the default for a bare -P), json (JSON progress events)",
+	"flag.progress-fd":         "file descriptor for --progress=json events, standard error by default",
+	"flag.stats":               "print statistics after the search, including skipped files and time per phase",
+	"flag.quiet":               "print nothing and exit on the first match; use the exit status to check for matches",
+	"flag.no-messages":         "suppress error messages about unreadable files",
+	"flag.lang":                "interface language: zh-CN, en; detected from LC_ALL, LC_MESSAGES and LANG by default",
+	"flag.json":                "print results as JSON Lines",
+	"flag.vimgrep":             "print every match as path:line:col:text for editor quickfix lists",
+	"flag.output-format":       "output format: text, json, sarif, vimgrep, csv, tsv",
+	"flag.rule-id":             "rule ID in SARIF output, defaults to the search pattern",
+	"flag.path-style":          "path display style: relative (to the search path), absolute, basename",
+	"flag.null":                "print a NUL byte after paths instead of a newline or colon, for use with xargs -0",
+	"flag.hyperlink":           "wrap printed paths in OSC 8 terminal hyperlinks",
+	"flag.hyperlink-format":    "hyperlink URL format with {path} {host} {line} {column}, e.g. 'vscode://file{path}:{line}:{column}'",
+	"flag.report":              "write results to a self-contained HTML report, e.g. report.html",
+	"flag.report-context":      "lines of context around matches in the HTML report",
+	"flag.format":              "print each result with a Go text/template, e.g. '{{.Path}}:{{.Line}} {{.Text}}'",
+	"flag.format-file":         "read the output template from a file",
+	"flag.sort":                "sort results by: path, size, mtime, matches, none (completion order); walk order by default",
+	"flag.sort-reverse":        "sort in descending order",
+	"flag.metadata":            "show file type, size and modification time",
+	"flag.csv":                 "print results as CSV, same as --output-format csv",
+	"flag.tsv":                 "print results as TSV, same as --output-format tsv",
+	"flag.columns":             "CSV/TSV columns: path,name,ext,size,mode,owner,group,mtime,atime,inode,nlink,sha256",
+	"flag.recursive":           "search subdirectories recursively",
+	"flag.max-depth":           "maximum recursion depth, -1 for unlimited",
+	"flag.exclude-dir":         "directories to exclude",
+	"flag.include-ext":         "only include files with these extensions",
+	"flag.exclude-ext":         "exclude files with these extensions",
+	"flag.workers":             "number of parallel workers",
+	"flag.timeout":             "search timeout, e.g. 10s, 2m",
+	"flag.after-context":       "lines to show after each match",
+	"flag.before-context":      "lines to show before each match",
+	"flag.context":             "lines to show before and after each match",
+	"flag.count":               "only print the number of matching lines per file",
+	"flag.count-matches":       "only print the number of matches per file",
+	"flag.files-with-matches":  "only print paths of files with matches",
+	"flag.files-without-match": "only print paths of files without matches",
+	"flag.heading":             "group matches by file and print each path once as a heading (default on a terminal)",
+	"flag.no-heading":          "print the path on every matching line (default when piped or redirected)",
+	"flag.only-matching":       "only print the matched text, one match per line",
+	"flag.replace":             "print matches using a replacement template with $1, ${1} and ${name} groups; files are not modified",
+	"flag.pattern":             "search pattern; repeat to search for several patterns at once",
+	"flag.pattern-file":        "read search patterns from a file, one per line, ignoring empty lines; - reads standard input",
+	"flag.query":               "search with a boolean query supporting AND, OR, NOT, parentheses and NEAR/n, e.g. '\"Lock(\" AND \"Unlock(\" AND NOT defer'",
+	"flag.query-per-line":      "evaluate the --query per line instead of per file",
+	"flag.word-regexp":         "only match whole words: the match must not be preceded or followed by a letter, digit or underscore (Unicode-aware)",
+	"flag.line-regexp":         "only match whole lines",
+	"flag.invert-match":        "print lines that do not match; with -l/-L the file list is inverted instead, so -l prints files in which no line matches",
+	"flag.mode":                "initial search mode: name, content, regex; press Tab to switch in the interface",
+
+	// 参数错误
+	"error.invalid_lang":           "unsupported language: %s, available languages: %s",
+	"error.invalid_path_style":     "invalid path style: %s",
+	"error.sort_matches_name":      "--sort matches cannot be used with file name search",
+	"error.invalid_sort":           "invalid sort order: %s",
+	"error.sort_reverse":           "--sort-reverse requires --sort path|size|mtime|matches",
+	"error.format_conflict":        "--format and --format-file cannot be used together",
+	"error.quiet_report":           "--quiet cannot be combined with --report because --quiet stops at the first result",
+	"error.read_format_file":       "failed to read template file: %v",
+	"error.format_alias_conflict":  "--%s and --output-format %s cannot be used together",
+	"error.format_name_only":       "--output-format %s can only be used with file name search",
+	"error.invalid_output_format":  "invalid output format: %s",
+	"error.template_output_format": "--format cannot be used with --output-format %s",
+	"error.invalid_progress":       "invalid progress mode: %s",
+	"error.invalid_progress_fd":    "invalid file descriptor: %d",
+	"error.progress_fd_closed":     "file descriptor %d is not open: %v",
+	"error.invalid_color":          "invalid color mode: %s",
+	"error.start_pager":            "failed to start pager: %v",
+	"error.invalid_mode":           "invalid search mode: %s",
+	"error.tui":                    "failed to start interactive interface: %v",
+	"error.open_editor":            "failed to open editor: %v",
+	"error.no_patterns":            "no search pattern given; use a positional argument, -e or -f",
+	"error.read_pattern_file":      "failed to read pattern file: %v",
+	"error.invalid_regex":          "invalid regular expression: %v",
+	"error.query_with_patterns":    "--query cannot be combined with a positional pattern, -e or -f",
+	"error.query_per_line":         "--query-per-line requires --query",
+	"error.invalid_query":          "invalid query: %v",
+	"error.invert_only_matching":   "--invert-match cannot be combined with --only-matching",
+
+	// 搜索过程
+	"search.path_not_found": "error: search path does not exist: %s",
+	"search.error":          "error: %v",
+	"search.walk_error":     "error during search: %v",
+	"search.file_errors":    "some files were skipped because of read errors",
+	"search.progress":       "searching",
+	"search.no_files":       "no files found",
+
+	// 匹配
+	"matcher.file_too_large": "file too large, skipped",
+	"matcher.scan_failed":    "failed to scan file",
+
+	// 结果摘要
+	"summary.unmatched_timeout": "search timed out, found %d files without matches so far",
+	"summary.unmatched":         "found %d files without matches",
+	"summary.lines_timeout":     "search timed out, found %[2]d matching lines in %[1]d files so far",
+	"summary.files_timeout":     "search timed out, found %d matching files so far",
+	"summary.none":              "no matching files found",
+	"summary.lines":             "found %[2]d matching lines in %[1]d files",
+	"summary.files":             "found %d matching files",
+
+	// 统计信息
+	"stats.title":             "Statistics:",
+	"stats.dirs":              "  directories visited: %d",
+	"stats.considered":        "  files considered: %d",
+	"stats.searched":          "  files searched: %d",
+	"stats.bytes":             "  bytes read: %s (%.2f MB/s)",
+	"stats.skipped":           "  skipped: %s",
+	"stats.elapsed":           "  elapsed: %s",
+	"stats.skip.excluded_dir": "excluded dirs",
+	"stats.skip.extension":    "extension filter",
+	"stats.skip.too_large":    "over 10MB",
+	"stats.skip.scan_error":   "scan errors",
+	"stats.skip.permission":   "permission denied",
+	"stats.skip.error":        "other errors",
+	"stats.phase.count":       "counting files",
+	"stats.phase.walk":        "walk",
+	"stats.phase.search":      "search",
+	"stats.phase.output":      "output",
+
+	// 输出
+	"output.dir":              "[dir]",
+	"output.file":             "[file]",
+	"output.parse_template":   "failed to parse output template: %v",
+	"output.execute_template": "failed to execute output template: %s - %v",
+	"output.unknown_column":   "unknown column: %s, available columns: %s",
+	"output.no_columns":       "at least one column is required",
+	"output.write_failed":     "failed to write output: %v",
+	"output.sarif_message":    "pattern: %s",
+
+	// HTML 报告
+	"report.failed":              "failed to write report: %v",
+	"report.title":               "gost search report",
+	"report.pattern":             "Pattern",
+	"report.root":                "Search path",
+	"report.filters":             "Filters",
+	"report.filter_separator":    "; ",
+	"report.files":               "Matching files",
+	"report.matches":             "Matching lines",
+	"report.unmatched":           "Files without matches",
+	"report.elapsed":             "Elapsed",
+	"report.timed_out":           "(search timed out, results are incomplete)",
+	"report.generated":           "Generated",
+	"report.filter_placeholder":  "Filter by path or content…",
+	"report.no_matches":          "No matching files found",
+	"report.match_lines":         "%d matching lines",
+	"report.filter.ignore_case":  "ignore case",
+	"report.filter.max_depth":    "max depth: %d",
+	"report.filter.exclude_dirs": "excluded dirs: %s",
+	"report.filter.include_exts": "included extensions: %s",
+	"report.filter.exclude_exts": "excluded extensions: %s",
+	"report.filter.without":      "only files without matches",
+
+	// 查询语法
+	"query.no_positive":      "the query needs at least one term outside NOT, otherwise no lines can be printed",
+	"query.unclosed_quote":   "unclosed quote in query",
+	"query.empty_term":       "query terms cannot be empty",
+	"query.invalid_near":     "invalid operator %s, expected NEAR/n where n is the maximum distance in lines",
+	"query.empty":            "the query is empty",
+	"query.unexpected":       "unexpected %s in query",
+	"query.missing_paren":    "missing closing parenthesis in query",
+	"query.leading_operator": "the query cannot start with %s",
+	"query.missing_operand":  "missing term after %s",
+
+	// 交互式界面
+	"tui.help":        "↑↓ select  Enter print  Ctrl-O edit  Tab mode  Ctrl-T ignore case  Ctrl-F filters  Esc quit",
+	"tui.ignore_case": "ignore case",
+	"tui.filters":     "filters",
+	"tui.error":       "error: %v",
+	"tui.empty":       "type a search pattern",
+	"tui.searching":   "searching… %d results so far",
+	"tui.truncated":   "too many results, showing the first %d",
+	"tui.results":     "%d results in %s",
+	"tui.binary":      "binary file, no preview",
+	"tui.no_terminal": "no terminal available",
+}
@@ -0,0 +1,113 @@
+// Package i18n 提供用户可见消息的多语言支持
+//
+// 消息通过键从当前语言的消息目录中查找，当前语言缺少的消息使用默认语言，
+// 两者都没有时返回键本身，便于发现遗漏的翻译。
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// 支持的语言
+const (
+	LangZhCN = "zh-CN"
+	LangEn   = "en"
+
+	// DefaultLang 无法从环境变量确定语言时使用的语言
+	DefaultLang = LangZhCN
+)
+
+// catalogs 各语言的消息目录
+var catalogs = map[string]map[string]string{
+	LangZhCN: zhCN,
+	LangEn:   en,
+}
+
+// current 当前使用的语言
+var current = DefaultLang
+
+// T 返回当前语言中 key 对应的消息，args 非空时按 fmt.Sprintf 格式化
+func T(key string, args ...interface{}) string {
+	msg, ok := catalogs[current][key]
+	if !ok {
+		msg, ok = catalogs[DefaultLang][key]
+	}
+	if !ok {
+		msg = key
+	}
+
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Error 输出时才按当前语言翻译的错误，值为消息键
+// 包级别的错误变量在解析 --lang 之前就已创建，不能在创建时翻译
+type Error string
+
+// Error 返回当前语言中的错误消息
+func (e Error) Error() string {
+	return T(string(e))
+}
+
+// Language 返回当前使用的语言
+func Language() string {
+	return current
+}
+
+// Languages 返回所有支持的语言
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// SetLanguage 设置当前语言，lang 可以是 zh-CN、en 或 zh_CN.UTF-8 这样的区域设置名
+func SetLanguage(lang string) error {
+	normalized, ok := Normalize(lang)
+	if !ok {
+		return fmt.Errorf(T("error.invalid_lang"), lang, strings.Join(Languages(), ", "))
+	}
+	current = normalized
+	return nil
+}
+
+// Normalize 将语言或区域设置名转换为支持的语言，不支持时返回 false
+func Normalize(lang string) (string, bool) {
+	// 去掉编码和修饰部分，例如 en_US.UTF-8、de_DE@euro
+	if i := strings.IndexAny(lang, ".@"); i >= 0 {
+		lang = lang[:i]
+	}
+	lang = strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+
+	switch {
+	case lang == "zh" || strings.HasPrefix(lang, "zh-"):
+		return LangZhCN, true
+	case lang == "en" || strings.HasPrefix(lang, "en-"):
+		return LangEn, true
+	}
+	return "", false
+}
+
+// Detect 按照 LC_ALL、LC_MESSAGES、LANG 的优先级从环境变量确定语言
+// 第一个非空的变量决定语言，不支持该语言时使用默认语言
+func Detect() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if lang, ok := Normalize(value); ok {
+			return lang
+		}
+		break
+	}
+	return DefaultLang
+}
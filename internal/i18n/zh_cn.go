@@ -0,0 +1,197 @@
+package i18n
+
+// zhCN 简体中文消息目录
+var zhCN = map[string]string{
+	// 命令
+	"cmd.root.short":    "文件搜索工具，支持按文件名和内容搜索",
+	"cmd.root.long":     "gost 是一个强大的文件搜索工具，可以快速在目录树中查找文件或内容",
+	"cmd.name.short":    "按文件名搜索",
+	"cmd.name.long":     "按文件名搜索，支持通配符(*和?)",
+	"cmd.content.short": "按文件内容搜索",
+	"cmd.content.long":  "按文件内容搜索，使用字符串匹配",
+	"cmd.regex.short":   "使用正则表达式搜索文件内容",
+	"cmd.regex.long":    "使用正则表达式搜索文件内容，支持完整的正则表达式语法",
+	"cmd.tui.short":     "交互式搜索界面",
+	"cmd.tui.long":      "全屏交互式搜索界面，输入搜索条件时实时更新结果，并预览选中结果所在的文件。按 Enter 输出选中的结果，按 Ctrl-O 使用 $EDITOR 打开",
+
+	// 参数说明
+	"flag.path":                "搜索路径",
+	"flag.ignore-case":         "忽略大小写",
+	"flag.color":               "颜色输出: auto(输出到终端时启用), always, never",
+	"flag.pager":               "输出到终端时使用 $PAGER（默认为 less）分页显示",
+	"flag.progress":            "显示进度: bar(进度条，单独使用 -P 时的默认值), json(输出 JSON 进度事件)",
+	"flag.progress-fd":         "--progress=json 时进度事件输出到的文件描述符，默认为标准错误",
+	"flag.stats":               "搜索结束后输出统计信息，包括跳过的文件和各阶段耗时",
+	"flag.quiet":               "不输出任何结果，找到第一个结果后立即退出，通过退出码判断是否找到",
+	"flag.no-messages":         "不输出无法读取的文件等错误信息",
+	"flag.lang":                "界面语言: zh-CN, en，默认根据 LC_ALL、LC_MESSAGES、LANG 确定",
+	"flag.json":                "以 JSON Lines 格式输出结果",
+	"flag.vimgrep":             "以 path:line:col:text 格式输出每处匹配，用于编辑器的 quickfix",
+	"flag.output-format":       "输出格式: text, json, sarif, vimgrep, csv, tsv",
+	"flag.rule-id":             "SARIF 输出中的规则 ID，默认使用搜索模式",
+	"flag.path-style":          "路径显示样式: relative(相对于搜索路径), absolute, basename",
+	"flag.null":                "在路径后输出 NUL 字符而不是换行或冒号，便于配合 xargs -0",
+	"flag.hyperlink":           "将输出的路径包装为 OSC 8 终端超链接",
+	"flag.hyperlink-format":    "超链接 URL 格式，支持 {path} {host} {line} {column}，例如 'vscode://file{path}:{line}:{column}'",
+	"flag.report":              "将搜索结果写入独立的 HTML 报告文件，例如 report.html",
+	"flag.report-context":      "HTML 报告中匹配片段前后的上下文行数",
+	"flag.format":              "使用 Go text/template 模板输出每个结果，例如 '{{.Path}}:{{.Line}} {{.Text}}'",
+	"flag.format-file":         "从文件读取输出模板",
+	"flag.sort":                "结果排序方式: path, size, mtime, matches, none(按完成顺序)，默认按遍历顺序",
+	"flag.sort-reverse":        "按排序字段倒序输出",
+	"flag.metadata":            "显示文件类型、大小和修改时间",
+	"flag.csv":                 "以 CSV 格式输出结果，等同于 --output-format csv",
+	"flag.tsv":                 "以 TSV 格式输出结果，等同于 --output-format tsv",
+	"flag.columns":             "CSV/TSV 输出的列: path,name,ext,size,mode,owner,group,mtime,atime,inode,nlink,sha256",
+	"flag.recursive":           "递归搜索子目录",
+	"flag.max-depth":           "最大递归深度，-1表示不限制",
+	"flag.exclude-dir":         "排除的目录",
+	"flag.include-ext":         "只包含的文件扩展名",
+	"flag.exclude-ext":         "排除的文件扩展名",
+	"flag.workers":             "并行工作线程数",
+	"flag.timeout":             "搜索超时时间，例如10s, 2m等",
+	"flag.after-context":       "显示匹配行之后的行数",
+	"flag.before-context":      "显示匹配行之前的行数",
+	"flag.context":             "显示匹配行前后的行数",
+	"flag.count":               "只输出每个文件的匹配行数",
+	"flag.count-matches":       "只输出每个文件的匹配次数",
+	"flag.files-with-matches":  "只输出包含匹配的文件路径",
+	"flag.files-without-match": "只输出不包含匹配的文件路径",
+	"flag.heading":             "按文件分组输出，文件路径只在每组开头输出一次（输出到终端时默认启用）",
+	"flag.no-heading":          "每个匹配单独一行输出路径（输出到管道或文件时默认启用）",
+	"flag.only-matching":       "只输出匹配的文本，每处匹配一行",
+	"flag.replace":             "使用替换模板输出匹配，支持 $1、${1} 和 ${name} 引用捕获组，不会修改文件",
+	"flag.pattern":             "搜索模式，可以多次指定以同时搜索多个模式",
+	"flag.pattern-file":        "从文件读取搜索模式，每行一个，忽略空行，- 表示标准输入",
+	"flag.query":               "按布尔查询搜索，支持 AND、OR、NOT、括号和 NEAR/n，例如 '\"Lock(\" AND \"Unlock(\" AND NOT defer'",
+	"flag.query-per-line":      "按行而不是按文件判断 --query 查询是否成立",
+	"flag.word-regexp":         "只匹配整个单词，匹配的前后不能是字母、数字或下划线（包括中文等非 ASCII 字符）",
+	"flag.line-regexp":         "只匹配整行",
+	"flag.invert-match":        "输出不匹配的行；与 -l/-L 一起使用时按文件取反，-l 输出没有任何一行匹配的文件",
+	"flag.mode":                "初始的搜索方式: name, content, regex，在界面中按 Tab 切换",
+
+	// 参数错误
+	"error.invalid_lang":           "不支持的语言: %s，可选的语言: %s",
+	"error.invalid_path_style":     "无效的路径样式: %s",
+	"error.sort_matches_name":      "--sort matches 不能用于文件名搜索",
+	"error.invalid_sort":           "无效的排序方式: %s",
+	"error.sort_reverse":           "--sort-reverse 需要与 --sort path|size|mtime|matches 一起使用",
+	"error.format_conflict":        "--format 和 --format-file 不能同时使用",
+	"error.quiet_report":           "--quiet 不能与 --report 同时使用，--quiet 找到第一个结果后即结束搜索",
+	"error.read_format_file":       "读取模板文件失败: %v",
+	"error.format_alias_conflict":  "--%s 和 --output-format %s 不能同时使用",
+	"error.format_name_only":       "--output-format %s 只能用于文件名搜索",
+	"error.invalid_output_format":  "无效的输出格式: %s",
+	"error.template_output_format": "--format 不能与 --output-format %s 同时使用",
+	"error.invalid_progress":       "无效的进度显示方式: %s",
+	"error.invalid_progress_fd":    "无效的文件描述符: %d",
+	"error.progress_fd_closed":     "文件描述符 %d 未打开: %v",
+	"error.invalid_color":          "无效的颜色模式: %s",
+	"error.start_pager":            "启动分页程序失败: %v",
+	"error.invalid_mode":           "无效的搜索方式: %s",
+	"error.tui":                    "无法启动交互式界面: %v",
+	"error.open_editor":            "打开编辑器失败: %v",
+	"error.no_patterns":            "没有指定搜索模式，请使用位置参数、-e 或 -f 指定",
+	"error.read_pattern_file":      "读取模式文件失败: %v",
+	"error.invalid_regex":          "无效的正则表达式: %v",
+	"error.query_with_patterns":    "--query 不能与位置参数、-e 或 -f 同时使用",
+	"error.query_per_line":         "--query-per-line 需要与 --query 一起使用",
+	"error.invalid_query":          "无效的查询: %v",
+	"error.invert_only_matching":   "--invert-match 不能与 --only-matching 同时使用",
+
+	// 搜索过程
+	"search.path_not_found": "错误: 搜索路径不存在: %s",
+	"search.error":          "错误: %v",
+	"search.walk_error":     "搜索过程中出错: %v",
+	"search.file_errors":    "部分文件因读取错误被跳过",
+	"search.progress":       "搜索中",
+	"search.no_files":       "没有找到文件",
+
+	// 匹配
+	"matcher.file_too_large": "文件过大，已跳过",
+	"matcher.scan_failed":    "扫描文件失败",
+
+	// 结果摘要
+	"summary.unmatched_timeout": "搜索超时，已找到 %d 个不包含匹配的文件",
+	"summary.unmatched":         "共找到 %d 个不包含匹配的文件",
+	"summary.lines_timeout":     "搜索超时，已在 %d 个文件中找到 %d 个匹配行",
+	"summary.files_timeout":     "搜索超时，已找到 %d 个匹配的文件",
+	"summary.none":              "没有找到匹配的文件",
+	"summary.lines":             "共在 %d 个文件中找到 %d 个匹配行",
+	"summary.files":             "共找到 %d 个匹配的文件",
+
+	// 统计信息
+	"stats.title":             "统计信息:",
+	"stats.dirs":              "  访问目录: %d",
+	"stats.considered":        "  检查文件: %d",
+	"stats.searched":          "  搜索文件: %d",
+	"stats.bytes":             "  读取数据: %s (%.2f MB/s)",
+	"stats.skipped":           "  跳过: %s",
+	"stats.elapsed":           "  耗时: %s",
+	"stats.skip.excluded_dir": "排除的目录",
+	"stats.skip.extension":    "扩展名过滤",
+	"stats.skip.too_large":    "超过 10MB",
+	"stats.skip.scan_error":   "扫描错误",
+	"stats.skip.permission":   "权限不足",
+	"stats.skip.error":        "其他错误",
+	"stats.phase.count":       "统计文件",
+	"stats.phase.walk":        "遍历目录",
+	"stats.phase.search":      "搜索",
+	"stats.phase.output":      "输出",
+
+	// 输出
+	"output.dir":              "[目录]",
+	"output.file":             "[文件]",
+	"output.parse_template":   "解析输出模板失败: %v",
+	"output.execute_template": "执行输出模板失败: %s - %v",
+	"output.unknown_column":   "未知的列: %s，可选的列: %s",
+	"output.no_columns":       "至少需要指定一列",
+	"output.write_failed":     "写入输出失败: %v",
+	"output.sarif_message":    "匹配模式: %s",
+
+	// HTML 报告
+	"report.failed":              "生成报告失败: %v",
+	"report.title":               "gost 搜索报告",
+	"report.pattern":             "搜索模式",
+	"report.root":                "搜索路径",
+	"report.filters":             "过滤条件",
+	"report.filter_separator":    "；",
+	"report.files":               "匹配文件",
+	"report.matches":             "匹配行",
+	"report.unmatched":           "未匹配文件",
+	"report.elapsed":             "耗时",
+	"report.timed_out":           "（搜索超时，结果不完整）",
+	"report.generated":           "生成时间",
+	"report.filter_placeholder":  "按路径或内容过滤…",
+	"report.no_matches":          "没有找到匹配的文件",
+	"report.match_lines":         "%d 个匹配行",
+	"report.filter.ignore_case":  "忽略大小写",
+	"report.filter.max_depth":    "最大深度: %d",
+	"report.filter.exclude_dirs": "排除目录: %s",
+	"report.filter.include_exts": "包含扩展名: %s",
+	"report.filter.exclude_exts": "排除扩展名: %s",
+	"report.filter.without":      "只列出不包含匹配的文件",
+
+	// 查询语法
+	"query.no_positive":      "查询至少需要一个不在 NOT 中的条件，否则无法输出匹配的行",
+	"query.unclosed_quote":   "查询中的引号没有闭合",
+	"query.empty_term":       "查询中的条件不能为空",
+	"query.invalid_near":     "无效的运算符 %s，应为 NEAR/n，n 为相距的最大行数",
+	"query.empty":            "查询为空",
+	"query.unexpected":       "查询中有多余的 %s",
+	"query.missing_paren":    "查询中缺少右括号",
+	"query.leading_operator": "查询不能以 %s 开头",
+	"query.missing_operand":  "%s 之后缺少条件",
+
+	// 交互式界面
+	"tui.help":        "↑↓ 选择  Enter 输出  Ctrl-O 编辑  Tab 切换方式  Ctrl-T 忽略大小写  Ctrl-F 过滤条件  Esc 退出",
+	"tui.ignore_case": "忽略大小写",
+	"tui.filters":     "过滤条件",
+	"tui.error":       "错误: %v",
+	"tui.empty":       "输入搜索条件",
+	"tui.searching":   "搜索中… 已找到 %d 个结果",
+	"tui.truncated":   "结果过多，只显示前 %d 个",
+	"tui.results":     "共 %d 个结果，耗时 %s",
+	"tui.binary":      "二进制文件，不显示预览",
+	"tui.no_terminal": "没有可用的终端",
+}
@@ -0,0 +1,170 @@
+package matcher
+
+import (
+	"sort"
+)
+
+// ahoCorasick 多模式字符串匹配自动机
+// 无论模式有多少个，每一行只需要扫描一遍，适合同时查找大量固定字符串
+type ahoCorasick struct {
+	nodes    []acNode
+	root     [256]int32 // 根节点的完整转移表，避免在根节点上反复查找
+	patterns []string
+	fold     bool // 是否忽略 ASCII 字母的大小写
+}
+
+// acNode 自动机中的一个状态
+type acNode struct {
+	edges []acEdge // 按字节排序的转移
+	fail  int32    // 失配时跳转的状态
+	out   []int32  // 在该状态结束的模式，包括通过失配链接可达的模式
+}
+
+// acEdge 一条转移
+type acEdge struct {
+	b    byte
+	next int32
+}
+
+// newAhoCorasick 根据模式创建自动机，空模式被忽略
+// fold 为 true 时模式和文本中的 ASCII 字母都按小写处理，因此只能用于 ASCII 模式
+func newAhoCorasick(patterns []string, fold bool) *ahoCorasick {
+	a := &ahoCorasick{
+		nodes:    []acNode{{}},
+		patterns: patterns,
+		fold:     fold,
+	}
+
+	// 构建字典树
+	for i, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		state := int32(0)
+		for j := 0; j < len(pattern); j++ {
+			b := a.lower(pattern[j])
+			next := a.edge(state, b)
+			if next < 0 {
+				next = int32(len(a.nodes))
+				a.nodes = append(a.nodes, acNode{})
+				a.addEdge(state, b, next)
+			}
+			state = next
+		}
+		a.nodes[state].out = append(a.nodes[state].out, int32(i))
+	}
+
+	// 按广度优先顺序计算失配链接，并合并失配状态的输出
+	var queue []int32
+	for b := 0; b < 256; b++ {
+		next := a.edge(0, byte(b))
+		if next < 0 {
+			next = 0
+		} else {
+			queue = append(queue, next)
+		}
+		a.root[b] = next
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for _, e := range a.nodes[state].edges {
+			fail := a.nodes[state].fail
+			for fail != 0 && a.edge(fail, e.b) < 0 {
+				fail = a.nodes[fail].fail
+			}
+			if next := a.edge(fail, e.b); next >= 0 && next != e.next {
+				fail = next
+			} else {
+				fail = 0
+			}
+			a.nodes[e.next].fail = fail
+			a.nodes[e.next].out = append(a.nodes[e.next].out, a.nodes[fail].out...)
+			queue = append(queue, e.next)
+		}
+	}
+
+	return a
+}
+
+// lower 忽略大小写时将 ASCII 大写字母转换为小写
+func (a *ahoCorasick) lower(b byte) byte {
+	if a.fold && 'A' <= b && b <= 'Z' {
+		return b + 'a' - 'A'
+	}
+	return b
+}
+
+// edge 返回状态在字节 b 上的转移，没有转移时返回 -1
+func (a *ahoCorasick) edge(state int32, b byte) int32 {
+	edges := a.nodes[state].edges
+	i := sort.Search(len(edges), func(i int) bool { return edges[i].b >= b })
+	if i < len(edges) && edges[i].b == b {
+		return edges[i].next
+	}
+	return -1
+}
+
+// addEdge 添加一条转移，保持转移按字节有序
+func (a *ahoCorasick) addEdge(state int32, b byte, next int32) {
+	edges := a.nodes[state].edges
+	i := sort.Search(len(edges), func(i int) bool { return edges[i].b >= b })
+	edges = append(edges, acEdge{})
+	copy(edges[i+1:], edges[i:])
+	edges[i] = acEdge{b: b, next: next}
+	a.nodes[state].edges = edges
+}
+
+// findAll 查找一行中所有模式的出现位置
+// 多个模式重叠时选择最左边的，起点相同时选择最长的，返回的区间互不重叠
+func (a *ahoCorasick) findAll(line string) []Span {
+	var found []Span
+	state := int32(0)
+	for i := 0; i < len(line); i++ {
+		b := a.lower(line[i])
+		for {
+			if state == 0 {
+				state = a.root[b]
+				break
+			}
+			if next := a.edge(state, b); next >= 0 {
+				state = next
+				break
+			}
+			state = a.nodes[state].fail
+		}
+
+		for _, p := range a.nodes[state].out {
+			pattern := a.patterns[p]
+			found = append(found, Span{Start: i + 1 - len(pattern), End: i + 1, Pattern: pattern})
+		}
+	}
+	return selectSpans(found)
+}
+
+// selectSpans 从可能重叠的匹配中选出互不重叠的匹配
+// 优先选择最左边的匹配，起点相同时选择最长的，再相同时保留先出现的
+func selectSpans(spans []Span) []Span {
+	if len(spans) <= 1 {
+		return spans
+	}
+
+	sort.SliceStable(spans, func(i, j int) bool {
+		if spans[i].Start != spans[j].Start {
+			return spans[i].Start < spans[j].Start
+		}
+		return spans[i].End > spans[j].End
+	})
+
+	selected := spans[:0]
+	end := -1
+	for _, span := range spans {
+		// 空匹配不能紧跟在上一处匹配之后，与 regexp 的 FindAll 保持一致
+		if span.Start < end || (span.Start == end && span.Start == span.End) {
+			continue
+		}
+		selected = append(selected, span)
+		end = span.End
+	}
+	return selected
+}
@@ -0,0 +1,169 @@
+package matcher
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAhoCorasickFindAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		fold     bool
+		line     string
+		want     []Span
+	}{
+		{
+			name:     "没有匹配",
+			patterns: []string{"foo", "bar"},
+			line:     "hello world",
+			want:     nil,
+		},
+		{
+			name:     "同一模式多次出现",
+			patterns: []string{"ab"},
+			line:     "ab ab",
+			want: []Span{
+				{Start: 0, End: 2, Pattern: "ab"},
+				{Start: 3, End: 5, Pattern: "ab"},
+			},
+		},
+		{
+			name:     "重叠的模式选择最左边的",
+			patterns: []string{"bcd", "abc"},
+			line:     "abcd",
+			want:     []Span{{Start: 0, End: 3, Pattern: "abc"}},
+		},
+		{
+			name:     "重叠的匹配之后继续查找",
+			patterns: []string{"aba"},
+			line:     "ababa aba",
+			want: []Span{
+				{Start: 0, End: 3, Pattern: "aba"},
+				{Start: 6, End: 9, Pattern: "aba"},
+			},
+		},
+		{
+			name:     "互为前缀的模式选择最长的",
+			patterns: []string{"he", "hell", "hello"},
+			line:     "hello",
+			want:     []Span{{Start: 0, End: 5, Pattern: "hello"}},
+		},
+		{
+			name:     "较长的模式未匹配完时使用较短的",
+			patterns: []string{"hello", "he"},
+			line:     "help",
+			want:     []Span{{Start: 0, End: 2, Pattern: "he"}},
+		},
+		{
+			name:     "一个模式是另一个的后缀",
+			patterns: []string{"she", "he", "hers"},
+			line:     "ushers",
+			want:     []Span{{Start: 1, End: 4, Pattern: "she"}},
+		},
+		{
+			name:     "起点更靠左的短匹配优先于更长的匹配",
+			patterns: []string{"ab", "bcde"},
+			line:     "abcde",
+			want:     []Span{{Start: 0, End: 2, Pattern: "ab"}},
+		},
+		{
+			name:     "相同的模式保留先出现的",
+			patterns: []string{"go", "go"},
+			line:     "go",
+			want:     []Span{{Start: 0, End: 2, Pattern: "go"}},
+		},
+		{
+			name:     "空模式被忽略",
+			patterns: []string{"", "x"},
+			line:     "axb",
+			want:     []Span{{Start: 1, End: 2, Pattern: "x"}},
+		},
+		{
+			name:     "区分大小写",
+			patterns: []string{"Go"},
+			line:     "go GO Go",
+			want:     []Span{{Start: 6, End: 8, Pattern: "Go"}},
+		},
+		{
+			name:     "忽略大小写",
+			patterns: []string{"Go"},
+			fold:     true,
+			line:     "go GO Go",
+			want: []Span{
+				{Start: 0, End: 2, Pattern: "Go"},
+				{Start: 3, End: 5, Pattern: "Go"},
+				{Start: 6, End: 8, Pattern: "Go"},
+			},
+		},
+		{
+			name:     "忽略大小写时模式中的大写字母",
+			patterns: []string{"TODO", "fixme"},
+			fold:     true,
+			line:     "// todo: FIXME",
+			want: []Span{
+				{Start: 3, End: 7, Pattern: "TODO"},
+				{Start: 9, End: 14, Pattern: "fixme"},
+			},
+		},
+		{
+			name:     "忽略大小写只影响 ASCII 字母",
+			patterns: []string{"é"},
+			fold:     true,
+			line:     "É é",
+			want:     []Span{{Start: 3, End: 5, Pattern: "é"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newAhoCorasick(tt.patterns, tt.fold).findAll(tt.line)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findAll(%q) = %+v, want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectSpans(t *testing.T) {
+	tests := []struct {
+		name  string
+		spans []Span
+		want  []Span
+	}{
+		{
+			name:  "起点相同时选择最长的",
+			spans: []Span{{Start: 0, End: 2}, {Start: 0, End: 5}, {Start: 0, End: 3}},
+			want:  []Span{{Start: 0, End: 5}},
+		},
+		{
+			name:  "选择最左边的并跳过与之重叠的",
+			spans: []Span{{Start: 2, End: 6}, {Start: 0, End: 3}, {Start: 3, End: 4}},
+			want:  []Span{{Start: 0, End: 3}, {Start: 3, End: 4}},
+		},
+		{
+			name:  "相邻的匹配都保留",
+			spans: []Span{{Start: 2, End: 4}, {Start: 0, End: 2}},
+			want:  []Span{{Start: 0, End: 2}, {Start: 2, End: 4}},
+		},
+		{
+			name:  "空匹配不能紧跟在上一处匹配之后",
+			spans: []Span{{Start: 0, End: 2}, {Start: 2, End: 2}, {Start: 3, End: 3}},
+			want:  []Span{{Start: 0, End: 2}, {Start: 3, End: 3}},
+		},
+		{
+			name:  "完全相同时保留先出现的",
+			spans: []Span{{Start: 0, End: 2, Pattern: "a"}, {Start: 0, End: 2, Pattern: "b"}},
+			want:  []Span{{Start: 0, End: 2, Pattern: "a"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectSpans(tt.spans)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selectSpans() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package matcher
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+// Boundary 匹配的边界要求，内容匹配器和正则表达式匹配器使用相同的规则
+type Boundary int
+
+const (
+	BoundaryNone Boundary = iota // 不限制边界
+	BoundaryWord                 // 匹配的前后不能是单词字符
+	BoundaryLine                 // 匹配必须是整行
+)
+
+// nonWord 匹配一个非单词字符
+// 单词字符包括所有语言的字母、数字、组合附加符号和下划线，因此中文和带重音的标识符也按单词处理
+const nonWord = `[^\pL\pN\pM_]`
+
+// boundedRegexp 要求匹配满足边界条件的正则表达式
+//
+// Go 的 regexp 不支持环视，\b 也只识别 ASCII 单词字符，因此将原来的表达式放在
+// 捕获组中，前后加上边界条件，匹配后只取捕获组的位置。
+type boundedRegexp struct {
+	head *regexp.Regexp // 从行首开始查找，边界可以是行首
+	rest *regexp.Regexp // 从上一处匹配之后查找，为空表示整行匹配，每行最多一处
+}
+
+// newBoundedRegexp 为正则表达式 expr 创建满足边界条件的正则表达式，flags 放在最外层，例如 (?i)
+func newBoundedRegexp(expr, flags string, boundary Boundary) *boundedRegexp {
+	if boundary == BoundaryLine {
+		return &boundedRegexp{head: regexp.MustCompile(flags + `^(` + expr + `)$`)}
+	}
+	return &boundedRegexp{
+		head: regexp.MustCompile(flags + `(?:^|` + nonWord + `)(` + expr + `)(?:` + nonWord + `|$)`),
+		rest: regexp.MustCompile(flags + nonWord + `(` + expr + `)(?:` + nonWord + `|$)`),
+	}
+}
+
+// findAll 返回一行中所有满足边界条件的匹配，格式与 FindAllStringSubmatchIndex 相同，
+// 下标 0 和 1 为匹配本身，之后为原表达式中的各个捕获组
+func (r *boundedRegexp) findAll(line string) [][]int {
+	loc := r.head.FindStringSubmatchIndex(line)
+	if loc == nil {
+		return nil
+	}
+	locs := [][]int{loc[2:]}
+	if r.rest == nil {
+		return locs
+	}
+
+	for {
+		// 上一处匹配之后的边界字符已被消耗，从它开始重新查找，使其可以作为下一处匹配之前的边界
+		prev := locs[len(locs)-1]
+		pos := prev[1]
+		if prev[0] == prev[1] {
+			// 空匹配之后至少前进一个字符，避免重复匹配同一位置
+			if pos >= len(line) {
+				return locs
+			}
+			_, size := utf8.DecodeRuneInString(line[pos:])
+			pos += size
+		}
+		if pos >= len(line) {
+			return locs
+		}
+
+		loc := r.rest.FindStringSubmatchIndex(line[pos:])
+		if loc == nil {
+			return locs
+		}
+		groups := loc[2:]
+		for i := range groups {
+			if groups[i] >= 0 {
+				groups[i] += pos
+			}
+		}
+		locs = append(locs, groups)
+	}
+}
@@ -2,57 +2,179 @@ package matcher
 
 import (
 	"context"
-	"os"
+	"regexp"
+	"sort"
 	"strings"
+	"unicode/utf8"
 )
 
-// ContentMatcher 提供文件内容匹配功能
+// ContentMatcher 提供文件内容匹配功能，可以同时查找多个固定字符串
 type ContentMatcher struct {
-	Pattern    string
+	Patterns   []string
 	IgnoreCase bool
+
+	// 忽略大小写时使用的正则表达式，保证匹配位置对应原始文本
+	foldReg *regexp.Regexp
+
+	// 多个模式时使用的 Aho-Corasick 自动机，忽略大小写且模式中有非 ASCII 字符时为空
+	ac *ahoCorasick
+
+	// 非空的模式，以及是否有空模式，空模式匹配所有行
+	literals []string
+	hasEmpty bool
+
+	// 要求整词或整行匹配时使用的正则表达式，不限制边界时为空
+	bounded *boundedRegexp
 }
 
-// NewContentMatcher 创建一个新的内容匹配器
-func NewContentMatcher(pattern string, ignoreCase bool) *ContentMatcher {
-	return &ContentMatcher{
-		Pattern:    pattern,
+// NewContentMatcher 创建一个新的内容匹配器，重复的模式只保留一个
+func NewContentMatcher(patterns []string, ignoreCase bool) *ContentMatcher {
+	m := &ContentMatcher{
+		Patterns:   uniquePatterns(patterns),
 		IgnoreCase: ignoreCase,
 	}
-}
-
-// MatchFile 检查文件内容是否匹配模式
-func (m *ContentMatcher) MatchFile(ctx context.Context, filePath string) (bool, error) {
-	// 打开文件
-	file, err := os.Open(filePath)
-	if err != nil {
-		return false, err
+	
+	ascii := true
+	for _, pattern := range m.Patterns {
+		if pattern == "" {
+			m.hasEmpty = true
+			continue
+		}
+		m.literals = append(m.literals, pattern)
+		ascii = ascii && isASCII(pattern)
+	}
+	if len(m.literals) == 0 {
+		return m
 	}
-	defer file.Close()
 	
-	// 获取文件信息
-	info, err := file.Stat()
-	if err != nil {
-		return false, err
+	if ignoreCase {
+		// 起点相同时正则表达式选择先出现的分支，按长度倒序排列使其选择最长的模式
+		quoted := make([]string, len(m.literals))
+		for i, pattern := range m.literals {
+			quoted[i] = regexp.QuoteMeta(pattern)
+		}
+		sort.SliceStable(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+		m.foldReg = regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
 	}
 	
-	size := info.Size()
+	// 多个模式时使用自动机，每行只需扫描一遍
+	// 忽略大小写时自动机只能处理 ASCII 字母，非 ASCII 模式仍然使用正则表达式
+	if len(m.literals) > 1 && (!ignoreCase || ascii) {
+		m.ac = newAhoCorasick(m.literals, ignoreCase)
+	}
 	
-	// 限制读取大小，避免处理大文件
-	if size > 10*1024*1024 { // 10MB
-		return false, nil
+	return m
+}
+
+// SetBoundary 设置匹配的边界要求，例如只匹配整个单词或整行
+// 限制边界时所有模式合并为一个正则表达式查找，不再使用自动机
+func (m *ContentMatcher) SetBoundary(boundary Boundary) {
+	if boundary == BoundaryNone {
+		m.bounded = nil
+		return
 	}
 	
-	// 读取文件内容
-	content := make([]byte, size)
-	_, err = file.Read(content)
-	if err != nil {
-		return false, err
+	// 与忽略大小写时相同，按长度倒序排列，使同一位置优先匹配最长的模式
+	quoted := make([]string, len(m.Patterns))
+	for i, pattern := range m.Patterns {
+		quoted[i] = regexp.QuoteMeta(pattern)
 	}
+	sort.SliceStable(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
 	
-	// 检查内容是否包含模式
+	flags := ""
 	if m.IgnoreCase {
-		return strings.Contains(strings.ToLower(string(content)), strings.ToLower(m.Pattern)), nil
+		flags = "(?i)"
+	}
+	m.bounded = newBoundedRegexp(strings.Join(quoted, "|"), flags, boundary)
+}
+
+// MatchFile 查找文件中所有匹配模式的行
+func (m *ContentMatcher) MatchFile(ctx context.Context, filePath string) ([]Match, error) {
+	return ScanFile(ctx, filePath, m.maxFileSize(), m.MatchLine)
+}
+
+// maxFileSize 内容搜索跳过超过 MaxContentSize 的文件
+func (m *ContentMatcher) maxFileSize() int64 {
+	return MaxContentSize
+}
+
+// String 返回搜索模式，多个模式以 | 分隔
+func (m *ContentMatcher) String() string {
+	return strings.Join(m.Patterns, "|")
+}
+
+// MatchLine 返回一行中所有匹配的区间
+func (m *ContentMatcher) MatchLine(line string) []Span {
+	if m.bounded != nil {
+		spans := toSpans(m.bounded.findAll(line))
+		m.setPatterns(line, spans)
+		return spans
+	}
+	
+	var spans []Span
+	switch {
+	case len(m.literals) == 0:
+	// 包含非 ASCII 字符的行中可能有与 ASCII 字母大小写等价的字符，例如开尔文符号 K，
+	// 自动机无法处理，此时使用正则表达式
+	case m.ac != nil && (!m.IgnoreCase || isASCII(line)):
+		spans = m.ac.findAll(line)
+	case m.IgnoreCase:
+		spans = toSpans(m.foldReg.FindAllStringIndex(line, -1))
+		m.setPatterns(line, spans)
+	default:
+		spans = findLiteral(line, m.literals[0])
+		m.setPatterns(line, spans)
 	}
 	
-	return strings.Contains(string(content), m.Pattern), nil
-}
\ No newline at end of file
+	// 空模式匹配所有行
+	if len(spans) == 0 && m.hasEmpty {
+		spans = []Span{{Start: 0, End: 0}}
+	}
+	return spans
+}
+
+// setPatterns 为每处匹配记录对应的模式
+func (m *ContentMatcher) setPatterns(line string, spans []Span) {
+	for i := range spans {
+		spans[i].Pattern = m.findPattern(line[spans[i].Start:spans[i].End])
+	}
+}
+
+// findPattern 返回与匹配文本对应的模式，优先选择完全相同的模式
+// 忽略大小写时匹配文本与模式可能只是大小写不同
+func (m *ContentMatcher) findPattern(text string) string {
+	for _, pattern := range m.Patterns {
+		if pattern == text {
+			return pattern
+		}
+	}
+	for _, pattern := range m.Patterns {
+		if strings.EqualFold(text, pattern) {
+			return pattern
+		}
+	}
+	return text
+}
+
+// uniquePatterns 去掉重复的模式，保持原来的顺序
+func uniquePatterns(patterns []string) []string {
+	seen := make(map[string]bool, len(patterns))
+	unique := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if !seen[pattern] {
+			seen[pattern] = true
+			unique = append(unique, pattern)
+		}
+	}
+	return unique
+}
+
+// isASCII 判断字符串是否只包含 ASCII 字符
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package matcher
+
+import "context"
+
+// InvertMatcher 反向匹配器，返回另一个匹配器不匹配的行
+// 每一行单独判断，因此与按文件判断的布尔查询一起使用时，查询按行判断
+type InvertMatcher struct {
+	Matcher Matcher
+}
+
+// NewInvertMatcher 创建一个返回 m 不匹配的行的匹配器
+func NewInvertMatcher(m Matcher) *InvertMatcher {
+	return &InvertMatcher{Matcher: m}
+}
+
+// MatchFile 查找文件中所有不匹配的行，结果中没有匹配区间，列号为 1
+func (m *InvertMatcher) MatchFile(ctx context.Context, filePath string) ([]Match, error) {
+	var matches []Match
+	err := scanLines(ctx, filePath, m.maxFileSize(), func(lineNum int, offset int64, line string) {
+		if len(m.Matcher.MatchLine(line)) == 0 {
+			matches = append(matches, Match{Line: lineNum, Column: 1, Offset: offset, Text: line})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// MatchLine 不匹配的行中没有可以标出的区间，因此总是返回 nil，结果只能通过 MatchFile 获取
+func (m *InvertMatcher) MatchLine(line string) []Span {
+	return nil
+}
+
+// String 返回原来的搜索模式
+func (m *InvertMatcher) String() string {
+	return m.Matcher.String()
+}
+
+// maxFileSize 与原来的匹配器相同
+func (m *InvertMatcher) maxFileSize() int64 {
+	return m.Matcher.maxFileSize()
+}
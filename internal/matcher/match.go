@@ -0,0 +1,87 @@
+package matcher
+
+import (
+	"bufio"
+	"strings"
+)
+
+// Span 表示一行中一处匹配的字节区间 [Start, End)
+type Span struct {
+	Start int
+	End   int
+
+	// 正则表达式匹配时整个匹配及各捕获组在行内的字节区间，与 regexp 的
+	// FindStringSubmatchIndex 返回值格式相同，未参与匹配的组为 -1
+	Groups []int
+
+	// 启用替换时，根据替换模板展开后的文本
+	Replacement string
+
+	// 匹配该区间的搜索模式，同时搜索多个模式时用于区分命中的是哪一个
+	Pattern string
+}
+
+// Match 表示文件中匹配的一行
+type Match struct {
+	Line   int    // 行号，从 1 开始
+	Column int    // 第一处匹配的列号，从 1 开始（按字节计算）
+	Offset int64  // 第一处匹配在文件中的字节偏移
+	Text   string // 匹配所在行的内容，不含换行符
+	Spans  []Span // 该行中的所有匹配区间
+}
+
+// newMatch 根据行信息和匹配区间创建匹配结果
+func newMatch(lineNum int, lineOffset int64, text string, spans []Span) Match {
+	return Match{
+		Line:   lineNum,
+		Column: spans[0].Start + 1,
+		Offset: lineOffset + int64(spans[0].Start),
+		Text:   text,
+		Spans:  spans,
+	}
+}
+
+// findLiteral 查找字符串在一行中的所有出现位置
+func findLiteral(line, pattern string) []Span {
+	if pattern == "" {
+		return []Span{{Start: 0, End: 0}}
+	}
+
+	var spans []Span
+	start := 0
+	for {
+		i := strings.Index(line[start:], pattern)
+		if i < 0 {
+			break
+		}
+		spans = append(spans, Span{Start: start + i, End: start + i + len(pattern)})
+		start += i + len(pattern)
+	}
+	return spans
+}
+
+// toSpans 将正则表达式返回的索引转换为匹配区间
+// locs 可以是 FindAllStringIndex 或 FindAllStringSubmatchIndex 的返回值
+func toSpans(locs [][]int) []Span {
+	if len(locs) == 0 {
+		return nil
+	}
+
+	spans := make([]Span, len(locs))
+	for i, loc := range locs {
+		spans[i] = Span{Start: loc[0], End: loc[1], Groups: loc}
+	}
+	return spans
+}
+
+// lineSplitter 包装 bufio.ScanLines，记录每行在文件中实际占用的字节数
+type lineSplitter struct {
+	advance int
+}
+
+// split 实现 bufio.SplitFunc
+func (s *lineSplitter) split(data []byte, atEOF bool) (int, []byte, error) {
+	advance, token, err := bufio.ScanLines(data, atEOF)
+	s.advance = advance
+	return advance, token, err
+}
@@ -0,0 +1,108 @@
+package matcher
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Lingbou/go-search-tools/internal/i18n"
+)
+
+// MaxContentSize 内容搜索处理的最大文件大小，也是所有匹配器的最大行长度
+// 正则表达式搜索不限制文件大小
+const MaxContentSize = 10 * 1024 * 1024 // 10MB
+
+// ErrFileTooLarge 文件超过匹配器的大小限制时返回，表示文件被跳过
+var ErrFileTooLarge error = i18n.Error("matcher.file_too_large")
+
+// ErrScanFailed 逐行扫描文件出错时返回，例如文件在读取过程中变大，某一行超过了长度限制
+var ErrScanFailed error = i18n.Error("matcher.scan_failed")
+
+// Matcher 查找文件中匹配的行
+// 内容搜索和正则表达式搜索使用同一个搜索流程，只有匹配器不同
+type Matcher interface {
+	// MatchFile 查找文件中所有匹配的行，ctx 被取消时返回 ctx.Err()
+	MatchFile(ctx context.Context, filePath string) ([]Match, error)
+
+	// MatchLine 返回一行中所有匹配的区间，没有匹配时返回 nil
+	MatchLine(line string) []Span
+
+	// String 返回搜索模式，用于结果摘要和报告
+	String() string
+
+	// maxFileSize 返回处理的最大文件大小，0 表示不限制
+	maxFileSize() int64
+}
+
+// 确保各个匹配器实现了 Matcher 接口
+var (
+	_ Matcher = (*ContentMatcher)(nil)
+	_ Matcher = (*RegexMatcher)(nil)
+	_ Matcher = (*QueryMatcher)(nil)
+	_ Matcher = (*InvertMatcher)(nil)
+)
+
+// ScanFile 逐行读取文件，使用 matchLine 查找每一行中的匹配
+// 所有匹配器使用相同的读取方式，超过 maxSize 的文件被跳过，maxSize 为 0 时不限制文件大小，
+// 新的匹配器只需要实现 MatchLine，MatchFile 直接调用该函数即可
+func ScanFile(ctx context.Context, filePath string, maxSize int64, matchLine func(line string) []Span) ([]Match, error) {
+	var matches []Match
+	err := scanLines(ctx, filePath, maxSize, func(lineNum int, offset int64, line string) {
+		if spans := matchLine(line); len(spans) > 0 {
+			matches = append(matches, newMatch(lineNum, offset, line, spans))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// scanLines 逐行读取文件，对每一行调用 fn，offset 为该行在文件中的字节偏移
+func scanLines(ctx context.Context, filePath string, maxSize int64, fn func(lineNum int, offset int64, line string)) error {
+	// 打开文件
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// 限制读取大小，避免处理大文件
+	if maxSize > 0 {
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		if info.Size() > maxSize {
+			return ErrFileTooLarge
+		}
+	}
+
+	// 创建扫描器，记录每行的字节偏移
+	// 单行最长为 MaxContentSize，内容搜索时与文件大小限制相同，因此不会因为行过长而失败
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxContentSize+1)
+	splitter := &lineSplitter{}
+	scanner.Split(splitter.split)
+
+	var offset int64
+
+	// 逐行扫描文件
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		// 检查是否超时
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		fn(lineNum, offset, scanner.Text())
+		offset += int64(splitter.advance)
+	}
+
+	// 检查扫描错误
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: %v", ErrScanFailed, err)
+	}
+
+	return nil
+}
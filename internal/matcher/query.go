@@ -0,0 +1,565 @@
+package matcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Lingbou/go-search-tools/internal/i18n"
+)
+
+// QueryMatcher 按布尔查询匹配文件内容
+//
+// 查询由条件和运算符组成，支持 AND、OR、NOT、括号分组以及 NEAR/n，
+// 每个条件由内容匹配器或正则表达式匹配器查找。默认按文件判断查询是否成立，
+// 成立时输出使查询成立的条件所在的行；按行判断时每一行单独判断。
+type QueryMatcher struct {
+	Query   string
+	PerLine bool // 是否按行判断查询条件，此时 NEAR/n 要求两边的条件在同一行
+
+	root  queryNode
+	terms []queryTerm
+	index map[string]int // 条件文本对应的下标，相同的条件只查找一次
+}
+
+// queryTerm 查询中的一个条件
+type queryTerm struct {
+	text    string
+	matcher Matcher
+}
+
+// NewQueryMatcher 解析查询并创建匹配器，newMatcher 为每个条件创建匹配器
+// 查询语法错误或 newMatcher 返回错误时返回错误
+func NewQueryMatcher(query string, perLine bool, newMatcher func(pattern string) (Matcher, error)) (*QueryMatcher, error) {
+	m := &QueryMatcher{
+		Query:   strings.TrimSpace(query),
+		PerLine: perLine,
+		index:   make(map[string]int),
+	}
+
+	p := &queryParser{matcher: m}
+	if err := p.tokenize(query); err != nil {
+		return nil, err
+	}
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	if root.canBeEmpty() {
+		return nil, errors.New(i18n.T("query.no_positive"))
+	}
+	m.root = root
+
+	for i := range m.terms {
+		term, err := newMatcher(m.terms[i].text)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", m.terms[i].text, err)
+		}
+		m.terms[i].matcher = term
+	}
+	return m, nil
+}
+
+// MatchFile 查找文件中使查询成立的行
+func (m *QueryMatcher) MatchFile(ctx context.Context, filePath string) ([]Match, error) {
+	if m.PerLine {
+		return ScanFile(ctx, filePath, m.maxFileSize(), m.MatchLine)
+	}
+
+	// 先找出所有条件所在的行，再按整个文件判断查询
+	matches, err := ScanFile(ctx, filePath, m.maxFileSize(), m.matchTerms)
+	if err != nil || len(matches) == 0 {
+		return nil, err
+	}
+
+	termLines := make([][]int, len(m.terms))
+	for _, match := range matches {
+		for _, term := range m.spanTerms(match.Spans) {
+			termLines[term] = append(termLines[term], match.Line)
+		}
+	}
+
+	ok, hits := m.root.eval(termLines)
+	if !ok {
+		return nil, nil
+	}
+
+	var result []Match
+	for _, match := range matches {
+		if terms, found := hits[match.Line]; found {
+			result = append(result, m.filterSpans(match, terms))
+		}
+	}
+	return result, nil
+}
+
+// MatchLine 按单独一行判断查询，成立时返回使查询成立的条件在行内的区间
+func (m *QueryMatcher) MatchLine(line string) []Span {
+	spans := m.matchTerms(line)
+	if len(spans) == 0 {
+		return nil
+	}
+
+	termLines := make([][]int, len(m.terms))
+	for _, term := range m.spanTerms(spans) {
+		termLines[term] = []int{1}
+	}
+
+	ok, hits := m.root.eval(termLines)
+	if !ok {
+		return nil
+	}
+	return m.filterSpans(Match{Text: line, Spans: spans}, hits[1]).Spans
+}
+
+// matchTerms 返回一行中所有条件的匹配区间，区间的 Pattern 为对应条件的文本
+// 不同条件的区间可能重叠，输出前由 filterSpans 处理
+func (m *QueryMatcher) matchTerms(line string) []Span {
+	var spans []Span
+	for _, term := range m.terms {
+		termSpans := term.matcher.MatchLine(line)
+		for i := range termSpans {
+			termSpans[i].Pattern = term.text
+		}
+		spans = append(spans, termSpans...)
+	}
+
+	// newMatch 使用第一个区间计算列号
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].Start < spans[j].Start })
+	return spans
+}
+
+// spanTerms 返回区间对应的条件下标，每个条件只出现一次
+func (m *QueryMatcher) spanTerms(spans []Span) []int {
+	var terms []int
+	for _, span := range spans {
+		terms = appendTerm(terms, m.index[span.Pattern])
+	}
+	return terms
+}
+
+// filterSpans 只保留使查询成立的条件的区间，并重新计算列号和偏移
+func (m *QueryMatcher) filterSpans(match Match, terms []int) Match {
+	var spans []Span
+	for _, span := range match.Spans {
+		if containsTerm(terms, m.index[span.Pattern]) {
+			spans = append(spans, span)
+		}
+	}
+	spans = selectSpans(spans)
+
+	lineOffset := match.Offset - int64(match.Column-1)
+	return newMatch(match.Line, lineOffset, match.Text, spans)
+}
+
+// String 返回查询文本
+func (m *QueryMatcher) String() string {
+	return m.Query
+}
+
+// maxFileSize 返回各个条件的匹配器中最大的文件大小限制，有条件不限制时不限制
+func (m *QueryMatcher) maxFileSize() int64 {
+	var size int64
+	for _, term := range m.terms {
+		limit := term.matcher.maxFileSize()
+		if limit == 0 {
+			return 0
+		}
+		size = max(size, limit)
+	}
+	return size
+}
+
+// addTerm 记录一个条件，返回它的下标
+func (m *QueryMatcher) addTerm(text string) int {
+	if i, ok := m.index[text]; ok {
+		return i
+	}
+	m.index[text] = len(m.terms)
+	m.terms = append(m.terms, queryTerm{text: text})
+	return len(m.terms) - 1
+}
+
+// queryHits 使查询成立的行，键为行号，值为该行中使查询成立的条件
+type queryHits map[int][]int
+
+// add 将另一组结果合并进来
+func (h queryHits) add(other queryHits) {
+	for line, terms := range other {
+		for _, term := range terms {
+			h[line] = appendTerm(h[line], term)
+		}
+	}
+}
+
+// appendTerm 添加条件下标，已存在时不重复添加
+func appendTerm(terms []int, term int) []int {
+	if containsTerm(terms, term) {
+		return terms
+	}
+	return append(terms, term)
+}
+
+// containsTerm 判断条件下标是否存在
+func containsTerm(terms []int, term int) bool {
+	for _, t := range terms {
+		if t == term {
+			return true
+		}
+	}
+	return false
+}
+
+// queryNode 查询语法树中的节点
+type queryNode interface {
+	// eval 根据每个条件所在的行（按行号升序）判断查询是否成立，
+	// 成立时同时返回使其成立的行
+	eval(termLines [][]int) (bool, queryHits)
+
+	// canBeEmpty 判断查询是否可能在没有任何条件匹配时成立，例如 NOT a
+	canBeEmpty() bool
+}
+
+// termNode 单个条件，文件中有匹配的行时成立
+type termNode struct {
+	term int
+}
+
+func (n *termNode) eval(termLines [][]int) (bool, queryHits) {
+	lines := termLines[n.term]
+	if len(lines) == 0 {
+		return false, nil
+	}
+	hits := make(queryHits, len(lines))
+	for _, line := range lines {
+		hits[line] = []int{n.term}
+	}
+	return true, hits
+}
+
+func (n *termNode) canBeEmpty() bool { return false }
+
+// andNode 两边都成立时成立
+type andNode struct {
+	left, right queryNode
+}
+
+func (n *andNode) eval(termLines [][]int) (bool, queryHits) {
+	ok, hits := n.left.eval(termLines)
+	if !ok {
+		return false, nil
+	}
+	ok, right := n.right.eval(termLines)
+	if !ok {
+		return false, nil
+	}
+	hits.add(right)
+	return true, hits
+}
+
+func (n *andNode) canBeEmpty() bool { return n.left.canBeEmpty() && n.right.canBeEmpty() }
+
+// orNode 任意一边成立时成立，只输出成立的一边的行
+type orNode struct {
+	left, right queryNode
+}
+
+func (n *orNode) eval(termLines [][]int) (bool, queryHits) {
+	leftOK, hits := n.left.eval(termLines)
+	rightOK, right := n.right.eval(termLines)
+	if !leftOK {
+		return rightOK, right
+	}
+	if rightOK {
+		hits.add(right)
+	}
+	return true, hits
+}
+
+func (n *orNode) canBeEmpty() bool { return n.left.canBeEmpty() || n.right.canBeEmpty() }
+
+// notNode 子查询不成立时成立，不输出任何行
+type notNode struct {
+	expr queryNode
+}
+
+func (n *notNode) eval(termLines [][]int) (bool, queryHits) {
+	if ok, _ := n.expr.eval(termLines); ok {
+		return false, nil
+	}
+	return true, queryHits{}
+}
+
+func (n *notNode) canBeEmpty() bool { return true }
+
+// nearNode 两边的匹配行相距不超过 distance 行时成立，只输出满足距离要求的行
+type nearNode struct {
+	left, right queryNode
+	distance    int
+}
+
+func (n *nearNode) eval(termLines [][]int) (bool, queryHits) {
+	ok, left := n.left.eval(termLines)
+	if !ok {
+		return false, nil
+	}
+	ok, right := n.right.eval(termLines)
+	if !ok {
+		return false, nil
+	}
+
+	rightLines := make([]int, 0, len(right))
+	for line := range right {
+		rightLines = append(rightLines, line)
+	}
+	sort.Ints(rightLines)
+
+	hits := make(queryHits)
+	for line, terms := range left {
+		// 找出距离 line 不超过 distance 的右侧行
+		i := sort.SearchInts(rightLines, line-n.distance)
+		for ; i < len(rightLines) && rightLines[i] <= line+n.distance; i++ {
+			hits.add(queryHits{line: terms})
+			hits.add(queryHits{rightLines[i]: right[rightLines[i]]})
+		}
+	}
+	return len(hits) > 0, hits
+}
+
+func (n *nearNode) canBeEmpty() bool { return false }
+
+// 查询中的词法单元类型
+type queryTokenKind int
+
+const (
+	tokenTerm queryTokenKind = iota
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenNear
+	tokenLParen
+	tokenRParen
+)
+
+// queryToken 查询中的一个词法单元
+type queryToken struct {
+	kind     queryTokenKind
+	text     string // 条件的文本，或运算符在查询中的原文
+	distance int    // NEAR/n 中的 n
+}
+
+// queryParser 查询解析器
+//
+// 优先级从高到低为 NOT、NEAR/n、AND、OR，相邻的条件之间省略运算符时按 AND 处理。
+// 运算符必须大写，其他单词都是条件；条件中包含空白、括号或与运算符相同时需要
+// 使用双引号，引号内的 \" 表示双引号本身。
+type queryParser struct {
+	tokens  []queryToken
+	pos     int
+	matcher *QueryMatcher
+}
+
+// tokenize 将查询拆分为词法单元
+func (p *queryParser) tokenize(query string) error {
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			p.tokens = append(p.tokens, queryToken{kind: tokenLParen, text: "("})
+			i++
+		case r == ')':
+			p.tokens = append(p.tokens, queryToken{kind: tokenRParen, text: ")"})
+			i++
+		case r == '"':
+			var b strings.Builder
+			closed := false
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && runes[i+1] == '"' {
+					b.WriteRune('"')
+					i++
+					continue
+				}
+				if runes[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				b.WriteRune(runes[i])
+			}
+			if !closed {
+				return errors.New(i18n.T("query.unclosed_quote"))
+			}
+			if b.Len() == 0 {
+				return errors.New(i18n.T("query.empty_term"))
+			}
+			p.tokens = append(p.tokens, queryToken{kind: tokenTerm, text: b.String()})
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune(`()"`, runes[i]) {
+				i++
+			}
+			token, err := wordToken(string(runes[start:i]))
+			if err != nil {
+				return err
+			}
+			p.tokens = append(p.tokens, token)
+		}
+	}
+	return nil
+}
+
+// wordToken 识别不带引号的单词，大写的运算符之外都是条件
+func wordToken(word string) (queryToken, error) {
+	switch word {
+	case "AND":
+		return queryToken{kind: tokenAnd, text: word}, nil
+	case "OR":
+		return queryToken{kind: tokenOr, text: word}, nil
+	case "NOT":
+		return queryToken{kind: tokenNot, text: word}, nil
+	}
+
+	if word == "NEAR" || strings.HasPrefix(word, "NEAR/") {
+		distance, err := strconv.Atoi(strings.TrimPrefix(word, "NEAR/"))
+		if err != nil || distance < 0 {
+			return queryToken{}, fmt.Errorf(i18n.T("query.invalid_near"), word)
+		}
+		return queryToken{kind: tokenNear, text: word, distance: distance}, nil
+	}
+	return queryToken{kind: tokenTerm, text: word}, nil
+}
+
+// parse 解析整个查询
+func (p *queryParser) parse() (queryNode, error) {
+	if len(p.tokens) == 0 {
+		return nil, errors.New(i18n.T("query.empty"))
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf(i18n.T("query.unexpected"), p.tokens[p.pos].text)
+	}
+	return node, nil
+}
+
+// parseOr 解析以 OR 连接的子查询
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(tokenOr) {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseAnd 解析以 AND 连接或直接相邻的子查询
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseNear()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if !p.accept(tokenAnd) && !p.startsOperand() {
+			return left, nil
+		}
+		right, err := p.parseNear()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+}
+
+// parseNear 解析以 NEAR/n 连接的子查询
+func (p *queryParser) parseNear() (queryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenNear {
+		distance := p.tokens[p.pos].distance
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &nearNode{left: left, right: right, distance: distance}
+	}
+	return left, nil
+}
+
+// parseUnary 解析 NOT、括号中的子查询或单个条件
+func (p *queryParser) parseUnary() (queryNode, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, p.missingOperand()
+	}
+
+	token := p.tokens[p.pos]
+	switch token.kind {
+	case tokenNot:
+		p.pos++
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{expr: expr}, nil
+	case tokenLParen:
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(tokenRParen) {
+			return nil, errors.New(i18n.T("query.missing_paren"))
+		}
+		return expr, nil
+	case tokenTerm:
+		p.pos++
+		return &termNode{term: p.matcher.addTerm(token.text)}, nil
+	}
+	return nil, p.missingOperand()
+}
+
+// accept 下一个词法单元为指定类型时跳过它并返回 true
+func (p *queryParser) accept(kind queryTokenKind) bool {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == kind {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// startsOperand 判断下一个词法单元是否可以开始一个子查询，用于省略 AND 的情况
+func (p *queryParser) startsOperand() bool {
+	if p.pos >= len(p.tokens) {
+		return false
+	}
+	switch p.tokens[p.pos].kind {
+	case tokenTerm, tokenNot, tokenLParen:
+		return true
+	}
+	return false
+}
+
+// missingOperand 返回运算符之后缺少条件的错误
+func (p *queryParser) missingOperand() error {
+	if p.pos == 0 {
+		return fmt.Errorf(i18n.T("query.leading_operator"), p.tokens[0].text)
+	}
+	return fmt.Errorf(i18n.T("query.missing_operand"), p.tokens[p.pos-1].text)
+}
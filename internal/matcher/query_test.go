@@ -0,0 +1,319 @@
+package matcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// newTestQuery 创建使用内容匹配器查找条件的查询匹配器
+func newTestQuery(query string, perLine bool) (*QueryMatcher, error) {
+	return NewQueryMatcher(query, perLine, func(pattern string) (Matcher, error) {
+		return NewContentMatcher([]string{pattern}, false), nil
+	})
+}
+
+// queryLines 在内容为 text 的文件中执行查询，返回输出的行号
+func queryLines(t *testing.T, query string, perLine bool, text string) []int {
+	t.Helper()
+
+	m, err := newTestQuery(query, perLine)
+	if err != nil {
+		t.Fatalf("NewQueryMatcher(%q) error: %v", query, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	matches, err := m.MatchFile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("MatchFile(%q) error: %v", query, err)
+	}
+
+	var lines []int
+	for _, match := range matches {
+		lines = append(lines, match.Line)
+	}
+	return lines
+}
+
+func TestNewQueryMatcherErrors(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr bool
+	}{
+		{query: "alpha", wantErr: false},
+		{query: "alpha AND NOT beta", wantErr: false},
+		{query: "NOT alpha beta", wantErr: false},
+		{query: "alpha NEAR/0 beta", wantErr: false},
+		{query: `"alpha beta" OR "say \"hi\""`, wantErr: false},
+
+		// 只有 NOT 的查询在没有任何条件匹配时也成立，无法输出任何行
+		{query: "NOT alpha", wantErr: true},
+		{query: "NOT (alpha OR beta)", wantErr: true},
+		{query: "NOT alpha AND NOT beta", wantErr: true},
+		{query: "alpha OR NOT beta", wantErr: true},
+		{query: "(NOT alpha) OR beta", wantErr: true},
+
+		{query: "", wantErr: true},
+		{query: "   ", wantErr: true},
+		{query: `""`, wantErr: true},
+		{query: `"alpha`, wantErr: true},
+		{query: "(alpha", wantErr: true},
+		{query: "alpha)", wantErr: true},
+		{query: "()", wantErr: true},
+		{query: "AND alpha", wantErr: true},
+		{query: "alpha OR", wantErr: true},
+		{query: "alpha AND OR beta", wantErr: true},
+		{query: "alpha NEAR beta", wantErr: true},
+		{query: "alpha NEAR/x beta", wantErr: true},
+		{query: "alpha NEAR/-1 beta", wantErr: true},
+		{query: "alpha NEAR/1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := newTestQuery(tt.query, false)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewQueryMatcher(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQueryMatcherPrecedence(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		text  string
+		want  []int
+	}{
+		{
+			name:  "AND 优先于 OR",
+			query: "alpha OR beta AND gamma",
+			text:  "alpha\n",
+			want:  []int{1},
+		},
+		{
+			name:  "括号改变优先级",
+			query: "(alpha OR beta) AND gamma",
+			text:  "alpha\n",
+			want:  nil,
+		},
+		{
+			name:  "括号中任意一边成立",
+			query: "(alpha OR beta) AND gamma",
+			text:  "beta\ngamma\n",
+			want:  []int{1, 2},
+		},
+		{
+			name:  "嵌套括号",
+			query: "((alpha OR beta) AND (gamma OR delta)) OR epsilon",
+			text:  "beta\ndelta\n",
+			want:  []int{1, 2},
+		},
+		{
+			name:  "省略 AND",
+			query: "alpha beta",
+			text:  "alpha\nbeta\n",
+			want:  []int{1, 2},
+		},
+		{
+			name:  "小写的 and 是条件而不是运算符",
+			query: "alpha and beta",
+			text:  "alpha\nbeta\n",
+			want:  nil,
+		},
+		{
+			name:  "OR 只输出成立的一边",
+			query: "alpha OR beta",
+			text:  "alpha\ngamma\n",
+			want:  []int{1},
+		},
+		{
+			name:  "NOT 只作用于紧随其后的条件",
+			query: "NOT alpha AND beta",
+			text:  "beta\n",
+			want:  []int{1},
+		},
+		{
+			name:  "NOT 的条件出现时不成立",
+			query: "NOT alpha AND beta",
+			text:  "alpha\nbeta\n",
+			want:  nil,
+		},
+		{
+			name:  "NOT 作用于括号中的子查询",
+			query: "NOT (alpha OR beta) gamma",
+			text:  "beta\ngamma\n",
+			want:  nil,
+		},
+		{
+			name:  "NOT 的行不输出",
+			query: "alpha AND NOT beta",
+			text:  "alpha\ngamma\n",
+			want:  []int{1},
+		},
+		{
+			name:  "NEAR 优先于 AND",
+			query: "alpha beta NEAR/1 gamma",
+			text:  "beta\ngamma\n\nalpha\n",
+			want:  []int{1, 2, 4},
+		},
+		{
+			name:  "带引号的条件包含空格和运算符",
+			query: `"alpha OR beta" OR gamma`,
+			text:  "alpha\nalpha OR beta\n",
+			want:  []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := queryLines(t, tt.query, false, tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("query %q = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryMatcherNear(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		text  string
+		want  []int
+	}{
+		{
+			name:  "距离等于 n",
+			query: "alpha NEAR/2 beta",
+			text:  "alpha\n\nbeta\n",
+			want:  []int{1, 3},
+		},
+		{
+			name:  "距离为 n+1",
+			query: "alpha NEAR/1 beta",
+			text:  "alpha\n\nbeta\n",
+			want:  nil,
+		},
+		{
+			name:  "距离小于 n",
+			query: "alpha NEAR/5 beta",
+			text:  "alpha\n\nbeta\n",
+			want:  []int{1, 3},
+		},
+		{
+			name:  "右侧的条件在前面",
+			query: "beta NEAR/2 alpha",
+			text:  "alpha\n\nbeta\n",
+			want:  []int{1, 3},
+		},
+		{
+			name:  "NEAR/0 要求在同一行",
+			query: "alpha NEAR/0 beta",
+			text:  "alpha beta\nalpha\nbeta\n",
+			want:  []int{1},
+		},
+		{
+			name:  "NEAR/0 不在同一行",
+			query: "alpha NEAR/0 beta",
+			text:  "alpha\nbeta\n",
+			want:  nil,
+		},
+		{
+			name:  "只输出满足距离要求的行",
+			query: "alpha NEAR/1 beta",
+			text:  "alpha\n\n\n\nalpha\nbeta\n",
+			want:  []int{5, 6},
+		},
+		{
+			// 从左到右结合，左侧子查询中只有与 gamma 相距不超过 1 行的行被输出
+			name:  "连续的 NEAR",
+			query: "alpha NEAR/1 beta NEAR/1 gamma",
+			text:  "alpha\nbeta\ngamma\n",
+			want:  []int{2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := queryLines(t, tt.query, false, tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("query %q = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryMatcherPerLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		text        string
+		wantFile    []int
+		wantPerLine []int
+	}{
+		{
+			name:        "AND",
+			query:       "alpha AND beta",
+			text:        "alpha\nbeta\nalpha beta\n",
+			wantFile:    []int{1, 2, 3},
+			wantPerLine: []int{3},
+		},
+		{
+			name:        "AND NOT",
+			query:       "alpha AND NOT beta",
+			text:        "alpha beta\nalpha\n",
+			wantFile:    nil,
+			wantPerLine: []int{2},
+		},
+		{
+			name:        "OR",
+			query:       "alpha OR beta",
+			text:        "alpha\ngamma\nbeta\n",
+			wantFile:    []int{1, 3},
+			wantPerLine: []int{1, 3},
+		},
+		{
+			name:        "NEAR",
+			query:       "alpha NEAR/1 beta",
+			text:        "alpha\nbeta\nalpha beta\n",
+			wantFile:    []int{1, 2, 3},
+			wantPerLine: []int{3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := queryLines(t, tt.query, false, tt.text); !reflect.DeepEqual(got, tt.wantFile) {
+				t.Errorf("query %q per file = %v, want %v", tt.query, got, tt.wantFile)
+			}
+			if got := queryLines(t, tt.query, true, tt.text); !reflect.DeepEqual(got, tt.wantPerLine) {
+				t.Errorf("query %q per line = %v, want %v", tt.query, got, tt.wantPerLine)
+			}
+		})
+	}
+}
+
+func TestQueryMatcherSpans(t *testing.T) {
+	// 只输出使查询成立的条件的区间
+	m, err := newTestQuery("alpha OR beta AND gamma", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := m.MatchLine("beta alpha")
+	want := []Span{{Start: 5, End: 10, Pattern: "alpha"}}
+	if len(got) != len(want) {
+		t.Fatalf("MatchLine() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i].Start != want[i].Start || got[i].End != want[i].End || got[i].Pattern != want[i].Pattern {
+			t.Errorf("MatchLine()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -1,71 +1,148 @@
 package matcher
 
 import (
-	"bufio"
 	"context"
-	"os"
 	"regexp"
-	// "strings"
+	"strings"
 )
 
-// RegexMatcher 正则表达式匹配器
+// RegexMatcher 正则表达式匹配器，可以同时使用多个正则表达式
 type RegexMatcher struct {
-	Pattern     string
-	IgnoreCase  bool
+	Patterns   []string
+	IgnoreCase bool
+	
+	// 只有一个模式时为该模式编译后的正则表达式
+	// 有多个模式时为所有模式合并后的正则表达式，用于快速排除不匹配的行
 	CompiledReg *regexp.Regexp
+	
+	// 有多个模式时各个模式编译后的正则表达式，用于确定每处匹配对应的模式
+	regs []*regexp.Regexp
+	
+	// 要求整词或整行匹配时各个模式对应的正则表达式，不限制边界时为空
+	bounded []*boundedRegexp
+
+	// 替换模板，支持 $1、${1} 和 ${name} 引用捕获组
+	replacement string
+	replace     bool
 }
 
-// NewRegexMatcher 创建一个新的正则表达式匹配器
-func NewRegexMatcher(pattern string, ignoreCase bool) *RegexMatcher {
+// NewRegexMatcher 创建一个新的正则表达式匹配器，正则表达式无效时返回错误
+func NewRegexMatcher(patterns []string, ignoreCase bool) (*RegexMatcher, error) {
 	// 处理忽略大小写
 	flags := ""
 	if ignoreCase {
 		flags = "(?i)"
 	}
 	
+	m := &RegexMatcher{
+		Patterns:   uniquePatterns(patterns),
+		IgnoreCase: ignoreCase,
+	}
+	
 	// 编译正则表达式
-	reg := regexp.MustCompile(flags + pattern)
+	for _, pattern := range m.Patterns {
+		reg, err := regexp.Compile(flags + pattern)
+		if err != nil {
+			return nil, err
+		}
+		m.regs = append(m.regs, reg)
+	}
+	if len(m.regs) == 1 {
+		m.CompiledReg = m.regs[0]
+		m.regs = nil
+		return m, nil
+	}
 	
-	return &RegexMatcher{
-		Pattern:     pattern,
-		IgnoreCase:  ignoreCase,
-		CompiledReg: reg,
+	// 合并为一个正则表达式，每个模式中的标志只作用于各自的分组
+	groups := make([]string, len(m.Patterns))
+	for i, pattern := range m.Patterns {
+		groups[i] = "(?:" + pattern + ")"
+	}
+	m.CompiledReg = regexp.MustCompile(flags + strings.Join(groups, "|"))
+	return m, nil
+}
+
+// SetBoundary 设置匹配的边界要求，例如只匹配整个单词或整行
+func (m *RegexMatcher) SetBoundary(boundary Boundary) {
+	m.bounded = nil
+	if boundary == BoundaryNone {
+		return
+	}
+	
+	flags := ""
+	if m.IgnoreCase {
+		flags = "(?i)"
+	}
+	for _, pattern := range m.Patterns {
+		m.bounded = append(m.bounded, newBoundedRegexp(pattern, flags, boundary))
 	}
 }
 
-// MatchFile 检查文件内容是否匹配正则表达式
-func (m *RegexMatcher) MatchFile(ctx context.Context, filePath string) (bool, error) {
-	// 打开文件
-	file, err := os.Open(filePath)
-	if err != nil {
-		return false, err
+// SetReplacement 设置替换模板，匹配结果中会包含展开后的替换文本
+// 替换只影响输出，不会修改文件
+func (m *RegexMatcher) SetReplacement(template string) {
+	m.replacement = template
+	m.replace = true
+}
+
+// MatchFile 查找文件中所有匹配正则表达式的行
+func (m *RegexMatcher) MatchFile(ctx context.Context, filePath string) ([]Match, error) {
+	return ScanFile(ctx, filePath, m.maxFileSize(), m.MatchLine)
+}
+
+// maxFileSize 正则表达式搜索不限制文件大小
+func (m *RegexMatcher) maxFileSize() int64 {
+	return 0
+}
+
+// MatchLine 返回一行中所有匹配正则表达式的区间，启用替换时包含展开后的替换文本
+// 有多个模式时，重叠的匹配中保留最左边的，起点相同时保留最长的
+func (m *RegexMatcher) MatchLine(line string) []Span {
+	if m.regs == nil {
+		return m.matchRegexp(0, line)
 	}
-	defer file.Close()
 	
-	// 创建扫描器
-	scanner := bufio.NewScanner(file)
+	// 大部分行不匹配任何模式，先用合并后的正则表达式排除
+	if !m.CompiledReg.MatchString(line) {
+		return nil
+	}
 	
-	// 逐行扫描文件
-	for scanner.Scan() {
-		// 检查是否超时
-		select {
-		case <-ctx.Done():
-			return false, ctx.Err()
-		default:
-			line := scanner.Text()
-			
-			// 使用正则表达式匹配
-			if m.CompiledReg.MatchString(line) {
-				return true, nil
-			}
-		}
+	var spans []Span
+	for i := range m.regs {
+		spans = append(spans, m.matchRegexp(i, line)...)
+	}
+	return selectSpans(spans)
+}
+
+// matchRegexp 返回一行中匹配第 index 个正则表达式的区间
+func (m *RegexMatcher) matchRegexp(index int, line string) []Span {
+	reg := m.CompiledReg
+	if m.regs != nil {
+		reg = m.regs[index]
 	}
 	
-	// 检查扫描错误
-	if err := scanner.Err(); err != nil {
-		return false, err
+	var locs [][]int
+	if m.bounded != nil {
+		locs = m.bounded[index].findAll(line)
+	} else {
+		locs = reg.FindAllStringSubmatchIndex(line, -1)
+	}
+	if len(locs) == 0 {
+		return nil
 	}
 	
-	// 没有找到匹配
-	return false, nil
-}
\ No newline at end of file
+	// 捕获组的编号与原来的正则表达式相同，因此替换时使用原来的正则表达式展开
+	spans := toSpans(locs)
+	for i := range spans {
+		spans[i].Pattern = m.Patterns[index]
+		if m.replace {
+			spans[i].Replacement = string(reg.ExpandString(nil, m.replacement, line, spans[i].Groups))
+		}
+	}
+	return spans
+}
+
+// String 返回搜索模式，多个模式以 | 分隔
+func (m *RegexMatcher) String() string {
+	return strings.Join(m.Patterns, "|")
+}
@@ -0,0 +1,13 @@
+//go:build darwin || freebsd || netbsd
+
+package output
+
+import (
+	"syscall"
+	"time"
+)
+
+// statAtime 返回文件的最后访问时间
+func statAtime(st *syscall.Stat_t) time.Time {
+	return time.Unix(st.Atimespec.Unix())
+}
@@ -0,0 +1,13 @@
+//go:build unix && !(linux || openbsd || dragonfly || solaris || illumos || darwin || freebsd || netbsd)
+
+package output
+
+import (
+	"syscall"
+	"time"
+)
+
+// statAtime 当前平台不支持获取访问时间，返回零值
+func statAtime(st *syscall.Stat_t) time.Time {
+	return time.Time{}
+}
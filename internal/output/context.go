@@ -0,0 +1,93 @@
+package output
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Lingbou/go-search-tools/internal/matcher"
+)
+
+// ContextGroups 读取文件，为匹配行补充前后上下文并按区间分组
+// 重叠或相邻的上下文窗口会合并为同一组
+func ContextGroups(path string, matches []matcher.Match, before, after int) ([][]Line, error) {
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// 需要读取到的最后一行
+	lastLine := matches[len(matches)-1].Line + after
+
+	matchIdx := 0
+	var groups [][]Line
+	var group []Line
+	var pending []Line // 尚未确定是否输出的前置上下文
+	afterLeft := 0     // 剩余需要输出的后置上下文行数
+	var offset int64
+
+	reader := bufio.NewReader(file)
+	for lineNum := 1; lineNum <= lastLine; lineNum++ {
+		raw, err := reader.ReadString('\n')
+		if raw == "" && err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		text := strings.TrimSuffix(strings.TrimSuffix(raw, "\n"), "\r")
+
+		if matchIdx < len(matches) && matches[matchIdx].Line == lineNum {
+			// 与上一组不相邻时开始新的分组
+			start := lineNum
+			if len(pending) > 0 {
+				start = pending[0].Line
+			}
+			if len(group) > 0 && group[len(group)-1].Line+1 < start {
+				groups = append(groups, group)
+				group = nil
+			}
+			group = append(group, pending...)
+			pending = nil
+			group = append(group, Line{Match: matches[matchIdx]})
+			matchIdx++
+			afterLeft = after
+		} else if afterLeft > 0 {
+			group = append(group, contextLine(lineNum, offset, text))
+			afterLeft--
+		} else if before > 0 {
+			pending = append(pending, contextLine(lineNum, offset, text))
+			if len(pending) > before {
+				pending = pending[1:]
+			}
+		}
+
+		offset += int64(len(raw))
+		if err != nil {
+			break
+		}
+	}
+
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// contextLine 创建一个上下文行
+func contextLine(lineNum int, offset int64, text string) Line {
+	return Line{
+		Match: matcher.Match{
+			Line:   lineNum,
+			Offset: offset,
+			Text:   text,
+		},
+		IsContext: true,
+	}
+}
@@ -0,0 +1,230 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/i18n"
+)
+
+// CSV/TSV 输出中可选的列
+var csvColumns = []string{
+	"path", "name", "ext", "size", "mode", "owner", "group",
+	"mtime", "atime", "inode", "nlink", "sha256",
+}
+
+// fileStat 与平台相关的文件信息
+type fileStat struct {
+	Uid   uint64
+	Gid   uint64
+	Inode uint64
+	Nlink uint64
+	Atime time.Time
+}
+
+// CSVPrinter 以 CSV 或 TSV 格式输出文件名搜索的结果，每个文件一行
+type CSVPrinter struct {
+	Config *config.SearchConfig
+
+	writer  *csv.Writer
+	columns []string
+	header  bool
+	err     error // 第一次写入出错时的错误
+
+	// 用户名和组名查询结果的缓存
+	users  map[uint64]string
+	groups map[uint64]string
+}
+
+// NewCSVPrinter 创建一个新的 CSV/TSV 输出器，sep 为字段分隔符
+func NewCSVPrinter(cfg *config.SearchConfig, w io.Writer, sep rune) (*CSVPrinter, error) {
+	columns, err := parseCSVColumns(cfg.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = sep
+	return &CSVPrinter{
+		Config:  cfg,
+		writer:  writer,
+		columns: columns,
+		users:   make(map[uint64]string),
+		groups:  make(map[uint64]string),
+	}, nil
+}
+
+// parseCSVColumns 解析以逗号分隔的列名
+func parseCSVColumns(spec string) ([]string, error) {
+	var columns []string
+	for _, column := range strings.Split(spec, ",") {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if column == "" {
+			continue
+		}
+		if !isCSVColumn(column) {
+			return nil, fmt.Errorf(i18n.T("output.unknown_column"), column, strings.Join(csvColumns, ", "))
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return nil, errors.New(i18n.T("output.no_columns"))
+	}
+	return columns, nil
+}
+
+// isCSVColumn 判断列名是否有效
+func isCSVColumn(name string) bool {
+	for _, column := range csvColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintFile 输出文件名搜索匹配到的文件
+func (p *CSVPrinter) PrintFile(path string, info os.FileInfo) {
+	p.writeHeader()
+	p.writer.Write(p.row(path, info))
+	p.flush()
+}
+
+// PrintResult CSV/TSV 只用于文件名搜索，内容搜索的结果不输出
+func (p *CSVPrinter) PrintResult(result *FileResult) {
+}
+
+// PrintSummary 没有任何结果时也输出表头，不输出汇总信息
+// 统计信息输出到标准错误
+func (p *CSVPrinter) PrintSummary(summary *Summary) {
+	p.writeHeader()
+	p.flush()
+	writeStats(os.Stderr, summary.Stats)
+}
+
+// Err 返回写入输出时的第一个错误
+func (p *CSVPrinter) Err() error {
+	return p.err
+}
+
+// flush 写出缓冲的内容，第一次出错时输出错误信息，例如磁盘已满
+func (p *CSVPrinter) flush() {
+	p.writer.Flush()
+	if err := p.writer.Error(); err != nil && p.err == nil {
+		fmt.Fprintln(os.Stderr, i18n.T("output.write_failed", err))
+		p.err = err
+	}
+}
+
+// writeHeader 输出表头，只输出一次
+func (p *CSVPrinter) writeHeader() {
+	if p.header {
+		return
+	}
+	p.header = true
+	p.writer.Write(p.columns)
+}
+
+// row 生成一个文件对应的行
+func (p *CSVPrinter) row(path string, info os.FileInfo) []string {
+	stat, hasStat := statInfo(info)
+
+	record := make([]string, len(p.columns))
+	for i, column := range p.columns {
+		switch column {
+		case "path":
+			record[i] = DisplayPath(p.Config, path)
+		case "name":
+			record[i] = info.Name()
+		case "ext":
+			record[i] = filepath.Ext(info.Name())
+		case "size":
+			record[i] = strconv.FormatInt(info.Size(), 10)
+		case "mode":
+			record[i] = info.Mode().String()
+		case "mtime":
+			record[i] = info.ModTime().Format(time.RFC3339)
+		case "owner":
+			if hasStat {
+				record[i] = p.lookupUser(stat.Uid)
+			}
+		case "group":
+			if hasStat {
+				record[i] = p.lookupGroup(stat.Gid)
+			}
+		case "atime":
+			if hasStat && !stat.Atime.IsZero() {
+				record[i] = stat.Atime.Format(time.RFC3339)
+			}
+		case "inode":
+			if hasStat && stat.Inode != 0 {
+				record[i] = strconv.FormatUint(stat.Inode, 10)
+			}
+		case "nlink":
+			if hasStat {
+				record[i] = strconv.FormatUint(stat.Nlink, 10)
+			}
+		case "sha256":
+			if !info.IsDir() {
+				record[i] = fileSHA256(path)
+			}
+		}
+	}
+	return record
+}
+
+// lookupUser 返回用户名，查询失败时返回数字 ID
+func (p *CSVPrinter) lookupUser(uid uint64) string {
+	if name, ok := p.users[uid]; ok {
+		return name
+	}
+
+	id := strconv.FormatUint(uid, 10)
+	name := id
+	if u, err := user.LookupId(id); err == nil {
+		name = u.Username
+	}
+	p.users[uid] = name
+	return name
+}
+
+// lookupGroup 返回组名，查询失败时返回数字 ID
+func (p *CSVPrinter) lookupGroup(gid uint64) string {
+	if name, ok := p.groups[gid]; ok {
+		return name
+	}
+
+	id := strconv.FormatUint(gid, 10)
+	name := id
+	if g, err := user.LookupGroupId(id); err == nil {
+		name = g.Name
+	}
+	p.groups[gid] = name
+	return name
+}
+
+// fileSHA256 计算文件内容的 SHA-256，读取失败时返回空字符串
+func fileSHA256(path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
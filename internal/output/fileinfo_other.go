@@ -0,0 +1,12 @@
+//go:build !unix && !windows
+
+package output
+
+import (
+	"os"
+)
+
+// statInfo 当前平台不支持获取扩展的文件信息
+func statInfo(info os.FileInfo) (fileStat, bool) {
+	return fileStat{}, false
+}
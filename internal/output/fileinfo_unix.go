@@ -0,0 +1,24 @@
+//go:build unix
+
+package output
+
+import (
+	"os"
+	"syscall"
+)
+
+// statInfo 返回文件的属主、属组、inode 和硬链接数
+func statInfo(info os.FileInfo) (fileStat, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fileStat{}, false
+	}
+
+	return fileStat{
+		Uid:   uint64(st.Uid),
+		Gid:   uint64(st.Gid),
+		Inode: uint64(st.Ino),
+		Nlink: uint64(st.Nlink),
+		Atime: statAtime(st),
+	}, true
+}
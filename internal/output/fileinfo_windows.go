@@ -0,0 +1,22 @@
+//go:build windows
+
+package output
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// statInfo 返回文件的访问时间，Windows 上没有属主、属组和 inode
+func statInfo(info os.FileInfo) (fileStat, bool) {
+	attr, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return fileStat{}, false
+	}
+
+	return fileStat{
+		Nlink: 1,
+		Atime: time.Unix(0, attr.LastAccessTime.Nanoseconds()),
+	}, true
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// hyperlinkURL 根据格式生成超链接的 URL
+// 支持的占位符：{path} 为以 / 开头的绝对路径，{host} 为主机名，
+// {line} 和 {column} 为行号和列号，未知时为 1
+func hyperlinkURL(format, host, path string, line, column int) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	abs = filepath.ToSlash(abs)
+	if !strings.HasPrefix(abs, "/") {
+		abs = "/" + abs // Windows 盘符路径
+	}
+
+	if line <= 0 {
+		line = 1
+	}
+	if column <= 0 {
+		column = 1
+	}
+
+	replacer := strings.NewReplacer(
+		"{path}", (&url.URL{Path: abs}).EscapedPath(),
+		"{host}", host,
+		"{line}", strconv.Itoa(line),
+		"{column}", strconv.Itoa(column),
+	)
+	return replacer.Replace(format)
+}
+
+// hostname 返回用于 file:// URL 的主机名，获取失败时返回空字符串
+func hostname() string {
+	host, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return host
+}
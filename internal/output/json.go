@@ -0,0 +1,198 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/matcher"
+)
+
+// JSON Lines 输出中的事件类型
+const (
+	EventBegin   = "begin"
+	EventMatch   = "match"
+	EventContext = "context"
+	EventEnd     = "end"
+	EventSummary = "summary"
+)
+
+// jsonEvent 一条 JSON Lines 事件
+type jsonEvent struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// jsonSubmatch 一行中的一处匹配
+type jsonSubmatch struct {
+	Match       string  `json:"match"`
+	Pattern     string  `json:"pattern,omitempty"` // 匹配的搜索模式
+	Replacement *string `json:"replacement,omitempty"`
+	Start       int     `json:"start"`
+	End         int     `json:"end"`
+}
+
+// jsonLine 匹配行或上下文行事件的数据
+type jsonLine struct {
+	Path       string         `json:"path"`
+	Line       int            `json:"line"`
+	Column     int            `json:"column,omitempty"`
+	Offset     int64          `json:"offset"`
+	Text       string         `json:"text"`
+	Submatches []jsonSubmatch `json:"submatches,omitempty"`
+}
+
+// jsonFile 文件名搜索匹配事件的数据
+type jsonFile struct {
+	Path    string    `json:"path"`
+	IsDir   bool      `json:"is_dir"`
+	Size    int64     `json:"size"`
+	Mode    string    `json:"mode"`
+	ModTime time.Time `json:"mtime"`
+}
+
+// jsonPath 文件开始事件的数据
+type jsonPath struct {
+	Path string `json:"path"`
+}
+
+// jsonEnd 文件结束事件的数据
+type jsonEnd struct {
+	Path       string `json:"path"`
+	Matches    int    `json:"matches"`    // 匹配的行数
+	Submatches int    `json:"submatches"` // 匹配的次数
+}
+
+// jsonSummary 汇总事件的数据
+type jsonSummary struct {
+	Files     int     `json:"files"`
+	Matches   int     `json:"matches"`
+	Unmatched int     `json:"unmatched"`
+	ElapsedMs float64 `json:"elapsed_ms"`
+	TimedOut  bool    `json:"timed_out"`
+
+	Stats *jsonStats `json:"stats,omitempty"`
+}
+
+// JSONPrinter 以 JSON Lines 格式输出结果，每行一个事件
+type JSONPrinter struct {
+	Config *config.SearchConfig
+
+	encoder *json.Encoder
+	mu      sync.Mutex
+}
+
+// NewJSONPrinter 创建一个新的 JSON Lines 输出器
+func NewJSONPrinter(cfg *config.SearchConfig, w io.Writer) *JSONPrinter {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &JSONPrinter{
+		Config:  cfg,
+		encoder: encoder,
+	}
+}
+
+// emit 写出一条事件
+func (p *JSONPrinter) emit(eventType string, data interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.encoder.Encode(jsonEvent{Type: eventType, Data: data})
+}
+
+// PrintFile 输出文件名搜索匹配到的文件
+func (p *JSONPrinter) PrintFile(path string, info os.FileInfo) {
+	p.emit(EventMatch, jsonFile{
+		Path:    DisplayPath(p.Config, path),
+		IsDir:   info.IsDir(),
+		Size:    info.Size(),
+		Mode:    info.Mode().String(),
+		ModTime: info.ModTime(),
+	})
+}
+
+// PrintResult 输出内容搜索中单个文件的匹配结果
+func (p *JSONPrinter) PrintResult(result *FileResult) {
+	cfg := p.Config
+	path := DisplayPath(cfg, result.Path)
+
+	// 计数和文件列表模式只输出文件结束事件
+	if !cfg.LineOutput() {
+		if cfg.FilesWithoutMatch == (len(result.Matches) == 0) {
+			p.emit(EventEnd, newJSONEnd(path, result.Matches))
+		}
+		return
+	}
+	if len(result.Matches) == 0 {
+		return
+	}
+
+	p.emit(EventBegin, jsonPath{Path: path})
+
+	if result.Groups == nil {
+		for _, m := range result.Matches {
+			p.emit(EventMatch, newJSONLine(path, Line{Match: m}, cfg.ReplaceEnabled))
+		}
+	} else {
+		for _, group := range result.Groups {
+			for _, line := range group {
+				eventType := EventMatch
+				if line.IsContext {
+					eventType = EventContext
+				}
+				p.emit(eventType, newJSONLine(path, line, cfg.ReplaceEnabled))
+			}
+		}
+	}
+
+	p.emit(EventEnd, newJSONEnd(path, result.Matches))
+}
+
+// PrintSummary 输出搜索结束后的汇总信息
+func (p *JSONPrinter) PrintSummary(summary *Summary) {
+	p.emit(EventSummary, jsonSummary{
+		Files:     summary.Files,
+		Matches:   summary.Matches,
+		Unmatched: summary.Unmatched,
+		ElapsedMs: float64(summary.Elapsed.Microseconds()) / 1000,
+		TimedOut:  summary.TimedOut,
+		Stats:     newJSONStats(summary.Stats),
+	})
+}
+
+// newJSONLine 将输出行转换为 JSON 事件数据
+// replace 为 true 时每处匹配附带替换文本
+func newJSONLine(path string, line Line, replace bool) jsonLine {
+	data := jsonLine{
+		Path:   path,
+		Line:   line.Line,
+		Column: line.Column,
+		Offset: line.Offset,
+		Text:   line.Text,
+	}
+	for _, span := range line.Spans {
+		submatch := jsonSubmatch{
+			Match:   line.Text[span.Start:span.End],
+			Start:   span.Start,
+			End:     span.End,
+			Pattern: span.Pattern,
+		}
+		if replace {
+			replacement := span.Replacement
+			submatch.Replacement = &replacement
+		}
+		data.Submatches = append(data.Submatches, submatch)
+	}
+	return data
+}
+
+// newJSONEnd 创建文件结束事件的数据
+func newJSONEnd(path string, matches []matcher.Match) jsonEnd {
+	return jsonEnd{
+		Path:       path,
+		Matches:    len(matches),
+		Submatches: CountMatches(matches),
+	}
+}
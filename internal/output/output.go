@@ -0,0 +1,169 @@
+package output
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/matcher"
+)
+
+// Line 输出中的一行，可能是匹配行或上下文行
+type Line struct {
+	matcher.Match
+	IsContext bool
+}
+
+// FileResult 单个文件的内容搜索结果
+type FileResult struct {
+	Index   int // 文件在遍历中的序号，用于按遍历顺序输出
+	Path    string
+	Info    os.FileInfo
+	Matches []matcher.Match
+	Err     error // 读取或匹配文件时出错，此时不输出结果
+
+	// 启用上下文时，按连续区间分组的输出行
+	Groups [][]Line
+}
+
+// Summary 搜索结束后的汇总信息
+type Summary struct {
+	Pattern   string        // 搜索模式
+	Files     int           // 匹配的文件数
+	Matches   int           // 匹配的行数，文件名搜索时为 0
+	Unmatched int           // 已搜索但没有匹配的文件数
+	Elapsed   time.Duration // 搜索耗时
+	TimedOut  bool          // 是否因超时而提前结束
+	Stats     *Stats        // 搜索过程的统计信息，未使用 --stats 时为 nil
+}
+
+// Printer 定义搜索结果的输出方式
+type Printer interface {
+	// PrintFile 输出文件名搜索匹配到的文件
+	PrintFile(path string, info os.FileInfo)
+	// PrintResult 输出内容搜索中单个文件的匹配结果，没有匹配的文件也会传入
+	PrintResult(result *FileResult)
+	// PrintSummary 输出搜索结束后的汇总信息
+	PrintSummary(summary *Summary)
+}
+
+// ErrorPrinter 由输出过程中可能失败的输出器实现，例如写入报告文件或执行模板出错
+// 错误出现时已经输出到标准错误，搜索结束后通过 Err 返回第一个错误，使退出码为 2
+type ErrorPrinter interface {
+	Err() error
+}
+
+// PrinterErr 返回输出器记录的第一个错误，输出器没有实现 ErrorPrinter 时返回 nil
+func PrinterErr(p Printer) error {
+	if ep, ok := p.(ErrorPrinter); ok {
+		return ep.Err()
+	}
+	return nil
+}
+
+// NewPrinter 根据配置创建输出器
+// 指定 --report 时，在正常输出的同时生成 HTML 报告；使用 --quiet 时不输出任何内容
+// --quiet 和 --report 不能同时使用，由命令行参数检查保证
+func NewPrinter(cfg *config.SearchConfig) (Printer, error) {
+	if cfg.Quiet {
+		return quietPrinter{}, nil
+	}
+
+	printer, err := newFormatPrinter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.ReportPath != "" {
+		printer = NewReportPrinter(cfg, printer)
+	}
+	return printer, nil
+}
+
+// newFormatPrinter 根据输出格式创建输出器
+func newFormatPrinter(cfg *config.SearchConfig) (Printer, error) {
+	switch {
+	case cfg.OutputFormat == config.OutputFormatJSON:
+		return NewJSONPrinter(cfg, os.Stdout), nil
+	case cfg.OutputFormat == config.OutputFormatSARIF:
+		return NewSARIFPrinter(cfg, os.Stdout), nil
+	case cfg.OutputFormat == config.OutputFormatVimgrep:
+		return NewVimgrepPrinter(cfg, os.Stdout), nil
+	case cfg.OutputFormat == config.OutputFormatCSV:
+		return NewCSVPrinter(cfg, os.Stdout, ',')
+	case cfg.OutputFormat == config.OutputFormatTSV:
+		return NewCSVPrinter(cfg, os.Stdout, '\t')
+	case cfg.Format != "":
+		return NewTemplatePrinter(cfg, os.Stdout)
+	default:
+		return NewTextPrinter(cfg), nil
+	}
+}
+
+// quietPrinter 不输出任何内容，搜索结果只通过退出码报告
+type quietPrinter struct{}
+
+func (quietPrinter) PrintFile(path string, info os.FileInfo) {}
+func (quietPrinter) PrintResult(result *FileResult)          {}
+func (quietPrinter) PrintSummary(summary *Summary)           {}
+
+// CountMatches 统计所有匹配行中的匹配次数
+// 反向匹配的行没有匹配区间，每行计为一次
+func CountMatches(matches []matcher.Match) int {
+	count := 0
+	for _, m := range matches {
+		count += max(len(m.Spans), 1)
+	}
+	return count
+}
+
+// replaceLine 将一行中的所有匹配替换为展开后的替换文本
+// 返回的匹配结果中，区间指向替换后文本中的位置
+func replaceLine(m matcher.Match) matcher.Match {
+	var b strings.Builder
+	spans := make([]matcher.Span, 0, len(m.Spans))
+	last := 0
+	for _, span := range m.Spans {
+		b.WriteString(m.Text[last:span.Start])
+		start := b.Len()
+		b.WriteString(span.Replacement)
+		spans = append(spans, matcher.Span{Start: start, End: b.Len(), Replacement: span.Replacement})
+		last = span.End
+	}
+	b.WriteString(m.Text[last:])
+
+	m.Text = b.String()
+	m.Spans = spans
+	return m
+}
+
+// onlyMatching 将一行匹配拆分为每处匹配一条结果，文本只包含匹配内容或替换文本
+func onlyMatching(m matcher.Match, replace bool) []matcher.Match {
+	var parts []matcher.Match
+	for _, span := range m.Spans {
+		if part, ok := onlyMatchingPart(m, span, replace); ok {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// onlyMatchingPart 返回一处匹配对应的结果，空匹配且没有替换文本时返回 false
+func onlyMatchingPart(m matcher.Match, span matcher.Span, replace bool) (matcher.Match, bool) {
+	text := m.Text[span.Start:span.End]
+	if replace {
+		text = span.Replacement
+	}
+	if text == "" {
+		return matcher.Match{}, false
+	}
+
+	return matcher.Match{
+		Line:   m.Line,
+		Column: span.Start + 1,
+		Offset: m.Offset + int64(span.Start-m.Spans[0].Start),
+		Text:   text,
+		Spans:  []matcher.Span{{Start: 0, End: len(text), Pattern: span.Pattern}},
+	}, true
+}
@@ -0,0 +1,27 @@
+package output
+
+import (
+	"path/filepath"
+
+	"github.com/Lingbou/go-search-tools/internal/config"
+)
+
+// DisplayPath 根据配置的路径样式返回用于显示的路径
+func DisplayPath(cfg *config.SearchConfig, path string) string {
+	switch cfg.PathStyle {
+	case config.PathStyleAbsolute:
+		if abs, err := filepath.Abs(path); err == nil {
+			return abs
+		}
+		return path
+	case config.PathStyleBasename:
+		return filepath.Base(path)
+	default:
+		// 搜索路径本身是文件时，相对路径为 "."，此时使用文件名
+		rel, err := filepath.Rel(cfg.SearchPath, path)
+		if err != nil || rel == "." {
+			return filepath.Base(path)
+		}
+		return rel
+	}
+}
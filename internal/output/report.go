@@ -0,0 +1,355 @@
+package output
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/i18n"
+	"github.com/Lingbou/go-search-tools/internal/matcher"
+	"github.com/Lingbou/go-search-tools/internal/utils"
+)
+
+// reportLine 报告中的一行匹配或上下文
+type reportLine struct {
+	Line    int
+	Context bool
+	HTML    template.HTML
+}
+
+// reportFile 报告中的一个文件
+type reportFile struct {
+	Name    string
+	Path    string
+	Matches int
+	Meta    string
+	Groups  [][]reportLine
+}
+
+// reportNode 报告文件树中的一个目录
+type reportNode struct {
+	Name  string
+	Dirs  []*reportNode
+	Files []*reportFile
+	Count int // 目录下（包括子目录）的文件数
+}
+
+// reportData 渲染报告模板使用的数据
+type reportData struct {
+	Pattern   string
+	Root      string
+	Filters   []string
+	Files     int
+	Matches   int
+	Unmatched int
+	Listed    int
+	Elapsed   string
+	Generated string
+	TimedOut  bool
+	Tree      *reportNode
+}
+
+// ReportPrinter 在正常输出的同时收集结果，搜索结束后生成独立的 HTML 报告
+type ReportPrinter struct {
+	Config *config.SearchConfig
+
+	next  Printer
+	files []*reportFile
+	err   error // 写出报告时的错误
+}
+
+// NewReportPrinter 创建一个新的报告输出器，next 负责正常的输出
+func NewReportPrinter(cfg *config.SearchConfig, next Printer) *ReportPrinter {
+	return &ReportPrinter{
+		Config: cfg,
+		next:   next,
+	}
+}
+
+// PrintFile 记录文件名搜索匹配到的文件
+func (p *ReportPrinter) PrintFile(path string, info os.FileInfo) {
+	p.next.PrintFile(path, info)
+
+	file := p.newFile(path)
+	if info != nil && !info.IsDir() {
+		file.Meta = fmt.Sprintf("%s · %s", utils.FormatSize(info.Size()), info.ModTime().Format("2006-01-02 15:04:05"))
+	}
+	p.files = append(p.files, file)
+}
+
+// PrintResult 记录内容搜索中单个文件的匹配结果
+func (p *ReportPrinter) PrintResult(result *FileResult) {
+	p.next.PrintResult(result)
+
+	cfg := p.Config
+	if cfg.FilesWithoutMatch != (len(result.Matches) == 0) {
+		return
+	}
+
+	file := p.newFile(result.Path)
+	file.Matches = len(result.Matches)
+	if len(result.Matches) > 0 {
+		file.Meta = i18n.T("report.match_lines", len(result.Matches))
+		file.Groups = p.snippets(result)
+	}
+	p.files = append(p.files, file)
+}
+
+// PrintSummary 输出汇总信息并写出 HTML 报告
+func (p *ReportPrinter) PrintSummary(summary *Summary) {
+	p.next.PrintSummary(summary)
+
+	if err := p.write(summary); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("report.failed", err))
+		p.err = err
+	}
+}
+
+// Err 返回写出报告或正常输出时的错误
+func (p *ReportPrinter) Err() error {
+	if p.err != nil {
+		return p.err
+	}
+	return PrinterErr(p.next)
+}
+
+// newFile 创建报告中的文件条目
+func (p *ReportPrinter) newFile(path string) *reportFile {
+	rel := relativeReportPath(p.Config.SearchPath, path)
+	return &reportFile{
+		Name: filepath.Base(rel),
+		Path: rel,
+	}
+}
+
+// snippets 返回带上下文和高亮的匹配片段
+func (p *ReportPrinter) snippets(result *FileResult) [][]reportLine {
+	groups := result.Groups
+	if groups == nil {
+		var err error
+		groups, err = ContextGroups(result.Path, result.Matches, p.Config.ReportContext, p.Config.ReportContext)
+		if err != nil {
+			groups = nil
+			for _, m := range result.Matches {
+				groups = append(groups, []Line{{Match: m}})
+			}
+		}
+	}
+
+	snippets := make([][]reportLine, 0, len(groups))
+	for _, group := range groups {
+		lines := make([]reportLine, 0, len(group))
+		for _, line := range group {
+			lines = append(lines, reportLine{
+				Line:    line.Line,
+				Context: line.IsContext,
+				HTML:    highlightHTML(line.Text, line.Spans),
+			})
+		}
+		snippets = append(snippets, lines)
+	}
+	return snippets
+}
+
+// write 渲染并写出报告文件
+func (p *ReportPrinter) write(summary *Summary) error {
+	cfg := p.Config
+
+	data := reportData{
+		Pattern:   summary.Pattern,
+		Root:      cfg.SearchPath,
+		Filters:   reportFilters(cfg),
+		Files:     summary.Files,
+		Matches:   summary.Matches,
+		Unmatched: summary.Unmatched,
+		Listed:    len(p.files),
+		Elapsed:   summary.Elapsed.Round(time.Millisecond).String(),
+		Generated: time.Now().Format("2006-01-02 15:04:05"),
+		TimedOut:  summary.TimedOut,
+		Tree:      buildReportTree(p.files),
+	}
+	if abs, err := filepath.Abs(cfg.SearchPath); err == nil {
+		data.Root = abs
+	}
+
+	file, err := os.Create(cfg.ReportPath)
+	if err != nil {
+		return err
+	}
+	if err := reportTemplate.Execute(file, data); err != nil {
+		file.Close()
+		return err
+	}
+	// 磁盘已满或网络文件系统上的写入错误可能在关闭文件时才返回
+	return file.Close()
+}
+
+// reportFilters 返回报告中展示的过滤条件
+func reportFilters(cfg *config.SearchConfig) []string {
+	var filters []string
+	if cfg.IgnoreCase {
+		filters = append(filters, i18n.T("report.filter.ignore_case"))
+	}
+	if cfg.MaxDepth >= 0 {
+		filters = append(filters, i18n.T("report.filter.max_depth", cfg.MaxDepth))
+	}
+	if len(cfg.ExcludeDirs) > 0 {
+		filters = append(filters, i18n.T("report.filter.exclude_dirs", strings.Join(cfg.ExcludeDirs, ", ")))
+	}
+	if len(cfg.IncludeExts) > 0 {
+		filters = append(filters, i18n.T("report.filter.include_exts", strings.Join(cfg.IncludeExts, ", ")))
+	}
+	if len(cfg.ExcludeExts) > 0 {
+		filters = append(filters, i18n.T("report.filter.exclude_exts", strings.Join(cfg.ExcludeExts, ", ")))
+	}
+	if cfg.FilesWithoutMatch {
+		filters = append(filters, i18n.T("report.filter.without"))
+	}
+	return filters
+}
+
+// relativeReportPath 返回相对于搜索路径、以 / 分隔的路径
+func relativeReportPath(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." {
+		rel = filepath.Base(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// buildReportTree 根据文件的相对路径构建目录树，目录和文件均按名称排序
+func buildReportTree(files []*reportFile) *reportNode {
+	root := &reportNode{}
+	for _, file := range files {
+		node := root
+		node.Count++
+		parts := strings.Split(file.Path, "/")
+		for _, dir := range parts[:len(parts)-1] {
+			node = node.child(dir)
+			node.Count++
+		}
+		node.Files = append(node.Files, file)
+	}
+	root.sort()
+	return root
+}
+
+// child 返回指定名称的子目录，不存在时创建
+func (n *reportNode) child(name string) *reportNode {
+	for _, dir := range n.Dirs {
+		if dir.Name == name {
+			return dir
+		}
+	}
+	dir := &reportNode{Name: name}
+	n.Dirs = append(n.Dirs, dir)
+	return dir
+}
+
+// sort 递归排序目录和文件
+func (n *reportNode) sort() {
+	sort.Slice(n.Dirs, func(i, j int) bool { return n.Dirs[i].Name < n.Dirs[j].Name })
+	sort.Slice(n.Files, func(i, j int) bool { return n.Files[i].Name < n.Files[j].Name })
+	for _, dir := range n.Dirs {
+		dir.sort()
+	}
+}
+
+// highlightHTML 转义一行文本并用 <mark> 标记匹配区间
+func highlightHTML(text string, spans []matcher.Span) template.HTML {
+	var b strings.Builder
+	last := 0
+	for _, span := range utils.MergeSpans(spans) {
+		b.WriteString(template.HTMLEscapeString(text[last:span.Start]))
+		b.WriteString("<mark>")
+		b.WriteString(template.HTMLEscapeString(text[span.Start:span.End]))
+		b.WriteString("</mark>")
+		last = span.End
+	}
+	b.WriteString(template.HTMLEscapeString(text[last:]))
+	return template.HTML(b.String())
+}
+
+// reportTemplate 报告的 HTML 模板，样式和脚本均内嵌，生成的文件可以单独分享
+// 模板中的文字通过 t 函数按当前语言输出
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"t":    func(key string) string { return i18n.T(key) },
+	"lang": i18n.Language,
+}).Parse(`<!DOCTYPE html>
+<html lang="{{lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{t "report.title"}} - {{.Pattern}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", "PingFang SC", "Microsoft YaHei", sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.4em; }
+table.summary td { padding: 2px 12px 2px 0; vertical-align: top; }
+table.summary td:first-child { color: #666; }
+code, pre { font-family: Menlo, Consolas, monospace; }
+#filter { width: 100%; max-width: 40em; padding: 6px; margin: 1em 0; font-size: 1em; }
+details { margin-left: 1.2em; }
+summary { cursor: pointer; padding: 2px 0; }
+.dir > summary { font-weight: bold; }
+.meta { color: #888; font-size: 0.85em; margin-left: 0.5em; }
+.snippet { border-collapse: collapse; margin: 4px 0 8px 1.2em; font-family: Menlo, Consolas, monospace; font-size: 0.85em; }
+.snippet td { padding: 0 8px; white-space: pre; }
+.snippet td.num { color: #999; text-align: right; user-select: none; }
+.snippet tr.ctx td.text { color: #777; }
+.snippet tr.sep td { height: 6px; border-top: 1px dashed #ccc; }
+mark { background: #ffe066; color: inherit; }
+.hidden { display: none; }
+.warn { color: #b45309; }
+</style>
+</head>
+<body>
+<h1>{{t "report.title"}}</h1>
+<table class="summary">
+<tr><td>{{t "report.pattern"}}</td><td><code>{{.Pattern}}</code></td></tr>
+<tr><td>{{t "report.root"}}</td><td><code>{{.Root}}</code></td></tr>
+{{if .Filters}}<tr><td>{{t "report.filters"}}</td><td>{{range $i, $f := .Filters}}{{if $i}}{{t "report.filter_separator"}}{{end}}{{$f}}{{end}}</td></tr>{{end}}
+<tr><td>{{t "report.files"}}</td><td>{{.Files}}</td></tr>
+{{if .Matches}}<tr><td>{{t "report.matches"}}</td><td>{{.Matches}}</td></tr>{{end}}
+{{if .Unmatched}}<tr><td>{{t "report.unmatched"}}</td><td>{{.Unmatched}}</td></tr>{{end}}
+<tr><td>{{t "report.elapsed"}}</td><td>{{.Elapsed}}{{if .TimedOut}} <span class="warn">{{t "report.timed_out"}}</span>{{end}}</td></tr>
+<tr><td>{{t "report.generated"}}</td><td>{{.Generated}}</td></tr>
+</table>
+<input id="filter" type="search" placeholder="{{t "report.filter_placeholder"}}" autofocus>
+<div id="tree">
+{{template "node" .Tree}}
+</div>
+{{if not .Listed}}<p>{{t "report.no_matches"}}</p>{{end}}
+<script>
+(function () {
+  var input = document.getElementById('filter');
+  var files = Array.prototype.slice.call(document.querySelectorAll('.file'));
+  var dirs = Array.prototype.slice.call(document.querySelectorAll('.dir')).reverse();
+  input.addEventListener('input', function () {
+    var q = input.value.toLowerCase();
+    files.forEach(function (f) {
+      var hit = !q || f.getAttribute('data-path').toLowerCase().indexOf(q) >= 0 ||
+        f.textContent.toLowerCase().indexOf(q) >= 0;
+      f.classList.toggle('hidden', !hit);
+      if (q && hit) { f.open = true; }
+    });
+    dirs.forEach(function (d) {
+      var visible = d.querySelector('.file:not(.hidden)') !== null;
+      d.classList.toggle('hidden', !visible);
+      if (q && visible) { d.open = true; }
+    });
+  });
+})();
+</script>
+</body>
+</html>
+{{define "node"}}{{range .Dirs}}<details class="dir" open><summary>{{.Name}}/<span class="meta">{{.Count}}</span></summary>
+{{template "node" .}}</details>
+{{end}}{{range .Files}}<details class="file" data-path="{{.Path}}"><summary>{{.Name}}{{if .Meta}}<span class="meta">{{.Meta}}</span>{{end}}</summary>
+{{if .Groups}}<table class="snippet">{{range $i, $g := .Groups}}{{if $i}}<tr class="sep"><td></td><td></td></tr>{{end}}{{range $g}}<tr{{if .Context}} class="ctx"{{end}}><td class="num">{{.Line}}</td><td class="text">{{.HTML}}</td></tr>{{end}}{{end}}</table>{{end}}
+</details>
+{{end}}{{end}}`))
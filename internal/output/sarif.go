@@ -0,0 +1,280 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/i18n"
+	"github.com/Lingbou/go-search-tools/internal/matcher"
+)
+
+// SARIF 日志的版本和模式
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// 文件路径相对于此基准目录
+	sarifRootBaseID = "SRCROOT"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds"`
+	ColumnKind         string                           `json:"columnKind"`
+	Results            []sarifResult                    `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+
+	pattern string // 匹配的搜索模式，为空时使用整个搜索的模式
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+	ContextRegion    *sarifRegion          `json:"contextRegion,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int          `json:"startLine"`
+	StartColumn int          `json:"startColumn,omitempty"`
+	EndColumn   int          `json:"endColumn,omitempty"`
+	Snippet     sarifMessage `json:"snippet"`
+}
+
+// SARIFPrinter 以 SARIF 2.1.0 格式输出结果
+// 结果会在内存中累积，搜索结束时一次性写出完整的日志
+type SARIFPrinter struct {
+	Config *config.SearchConfig
+
+	w       io.Writer
+	results []sarifResult
+}
+
+// NewSARIFPrinter 创建一个新的 SARIF 输出器
+func NewSARIFPrinter(cfg *config.SearchConfig, w io.Writer) *SARIFPrinter {
+	return &SARIFPrinter{
+		Config: cfg,
+		w:      w,
+	}
+}
+
+// PrintFile 记录文件名搜索匹配到的文件
+func (p *SARIFPrinter) PrintFile(path string, info os.FileInfo) {
+	p.addResult(path, nil, "")
+}
+
+// PrintResult 记录内容搜索中单个文件的匹配结果
+func (p *SARIFPrinter) PrintResult(result *FileResult) {
+	cfg := p.Config
+
+	// 计数和文件列表模式每个文件只记录一条结果
+	if !cfg.LineOutput() {
+		if cfg.FilesWithoutMatch == (len(result.Matches) == 0) {
+			p.addResult(result.Path, nil, "")
+		}
+		return
+	}
+
+	for _, m := range result.Matches {
+		// 反向匹配的行没有匹配区间，区域为整行
+		if len(m.Spans) == 0 {
+			p.addLineResult(result.Path, m, matcher.Span{End: len(m.Text)})
+			continue
+		}
+		for _, span := range m.Spans {
+			p.addLineResult(result.Path, m, span)
+		}
+	}
+}
+
+// PrintSummary 写出完整的 SARIF 日志
+// 每个搜索模式对应一条规则，便于代码扫描平台按模式分组；没有对应模式的结果，
+// 例如文件名搜索、文件列表模式和反向匹配，使用整个搜索的规则
+func (p *SARIFPrinter) PrintSummary(summary *Summary) {
+	var rules []sarifRule
+	ruleIndex := make(map[string]int)
+	addRule := func(pattern string) (string, int) {
+		id := p.ruleID(pattern, summary.Pattern)
+		index, ok := ruleIndex[id]
+		if !ok {
+			index = len(rules)
+			ruleIndex[id] = index
+			rules = append(rules, sarifRule{
+				ID:               id,
+				ShortDescription: sarifMessage{Text: i18n.T("output.sarif_message", pattern)},
+			})
+		}
+		return id, index
+	}
+
+	for i := range p.results {
+		result := &p.results[i]
+		pattern := result.pattern
+		if pattern == "" {
+			pattern = summary.Pattern
+		}
+		result.RuleID, result.RuleIndex = addRule(pattern)
+		result.Message.Text = i18n.T("output.sarif_message", pattern)
+	}
+	if len(rules) == 0 {
+		addRule(summary.Pattern)
+	}
+
+	results := p.results
+	if results == nil {
+		results = []sarifResult{}
+	}
+
+	log := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool: sarifTool{
+				Driver: sarifDriver{
+					Name:           "gost",
+					InformationURI: "https://github.com/Lingbou/go-search-tools",
+					Rules:          rules,
+				},
+			},
+			OriginalURIBaseIDs: map[string]sarifArtifactLocation{
+				sarifRootBaseID: {URI: rootURI(p.Config.SearchPath)},
+			},
+			ColumnKind: "unicodeCodePoints",
+			Results:    results,
+		}},
+	}
+
+	encoder := json.NewEncoder(p.w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(log)
+
+	// 统计信息输出到标准错误，避免破坏 SARIF 文件
+	writeStats(os.Stderr, summary.Stats)
+}
+
+// ruleID 返回搜索模式对应的规则 ID，默认使用模式本身
+// 指定 --rule-id 时整个搜索的规则使用该 ID，有多个模式时各模式的规则 ID 为 <rule-id>/<模式>
+func (p *SARIFPrinter) ruleID(pattern, searchPattern string) string {
+	switch {
+	case p.Config.RuleID == "":
+		return pattern
+	case pattern == searchPattern:
+		return p.Config.RuleID
+	default:
+		return p.Config.RuleID + "/" + pattern
+	}
+}
+
+// addResult 记录一条结果，region 为 nil 时只包含文件位置
+// pattern 为匹配的搜索模式，为空时使用整个搜索的规则
+func (p *SARIFPrinter) addResult(path string, region *sarifRegion, pattern string) {
+	p.results = append(p.results, sarifResult{
+		Level: "warning",
+		Locations: []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{
+					URI:       relativeURI(p.Config.SearchPath, path),
+					URIBaseID: sarifRootBaseID,
+				},
+				Region: region,
+			},
+		}},
+		pattern: pattern,
+	})
+}
+
+// addLineResult 记录一处匹配，区域的片段为匹配的文本，上下文区域的片段为所在的整行
+func (p *SARIFPrinter) addLineResult(path string, m matcher.Match, span matcher.Span) {
+	p.addResult(path, newSARIFRegion(m, span), span.Pattern)
+	location := &p.results[len(p.results)-1].Locations[0].PhysicalLocation
+	location.ContextRegion = &sarifRegion{
+		StartLine: m.Line,
+		Snippet:   sarifMessage{Text: m.Text},
+	}
+}
+
+// newSARIFRegion 根据一处匹配创建区域，列号按 Unicode 码点计算
+func newSARIFRegion(m matcher.Match, span matcher.Span) *sarifRegion {
+	return &sarifRegion{
+		StartLine:   m.Line,
+		StartColumn: utf8.RuneCountInString(m.Text[:span.Start]) + 1,
+		EndColumn:   utf8.RuneCountInString(m.Text[:span.End]) + 1,
+		Snippet:     sarifMessage{Text: m.Text[span.Start:span.End]},
+	}
+}
+
+// relativeURI 返回文件相对于搜索路径的 URI
+func relativeURI(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." {
+		rel = filepath.Base(path)
+	}
+	return (&url.URL{Path: filepath.ToSlash(rel)}).String()
+}
+
+// rootURI 返回搜索路径对应的 file:// URI，以 / 结尾
+// 搜索路径是文件时使用其所在目录
+func rootURI(root string) string {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		abs = root
+	}
+	if info, err := os.Stat(abs); err == nil && !info.IsDir() {
+		abs = filepath.Dir(abs)
+	}
+
+	path := filepath.ToSlash(abs)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // Windows 盘符路径
+	}
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
@@ -0,0 +1,121 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Lingbou/go-search-tools/internal/i18n"
+	"github.com/Lingbou/go-search-tools/internal/utils"
+)
+
+// 文件被跳过的原因
+const (
+	SkipExcludedDir = "excluded_dir" // 目录被 --exclude-dir 或 --max-depth 排除
+	SkipExtension   = "extension"    // 文件被扩展名过滤器排除
+	SkipTooLarge    = "too_large"    // 文件超过内容搜索的大小限制
+	SkipScanError   = "scan_error"   // 正则表达式搜索扫描文件出错，例如行过长
+	SkipPermission  = "permission"   // 没有读取权限
+	SkipOther       = "error"        // 其他读取错误
+)
+
+// skipReasons 按输出顺序排列的跳过原因，说明文字的消息键为 stats.skip.<原因>
+var skipReasons = []string{
+	SkipExcludedDir,
+	SkipExtension,
+	SkipTooLarge,
+	SkipScanError,
+	SkipPermission,
+	SkipOther,
+}
+
+// Stats 搜索过程的统计信息，使用 --stats 时随汇总信息一起输出
+type Stats struct {
+	DirsVisited     int            `json:"dirs_visited"`     // 访问的目录数
+	FilesConsidered int            `json:"files_considered"` // 遍历到的文件数
+	FilesSearched   int            `json:"files_searched"`   // 实际搜索的文件数
+	BytesRead       int64          `json:"bytes_read"`       // 读取的字节数
+	Skipped         map[string]int `json:"skipped"`          // 按原因统计的跳过数，排除的目录按目录计数
+
+	// 各阶段耗时，遍历和搜索同时进行，耗时会有重叠
+	CountElapsed  time.Duration `json:"-"` // 为进度条统计文件总数
+	WalkElapsed   time.Duration `json:"-"` // 遍历目录
+	SearchElapsed time.Duration `json:"-"` // 从开始遍历到所有文件搜索完成
+	OutputElapsed time.Duration `json:"-"` // 输出结果
+}
+
+// Throughput 返回搜索阶段的吞吐量，单位为 MB/s
+func (s *Stats) Throughput() float64 {
+	if s.SearchElapsed <= 0 {
+		return 0
+	}
+	return float64(s.BytesRead) / (1024 * 1024) / s.SearchElapsed.Seconds()
+}
+
+// jsonStats 汇总事件中的统计信息
+type jsonStats struct {
+	*Stats
+	CountMs    float64 `json:"count_ms"`
+	WalkMs     float64 `json:"walk_ms"`
+	SearchMs   float64 `json:"search_ms"`
+	OutputMs   float64 `json:"output_ms"`
+	Throughput float64 `json:"mb_per_sec"`
+}
+
+// newJSONStats 将统计信息转换为 JSON 事件数据，未启用统计时返回 nil
+func newJSONStats(stats *Stats) *jsonStats {
+	if stats == nil {
+		return nil
+	}
+	return &jsonStats{
+		Stats:      stats,
+		CountMs:    milliseconds(stats.CountElapsed),
+		WalkMs:     milliseconds(stats.WalkElapsed),
+		SearchMs:   milliseconds(stats.SearchElapsed),
+		OutputMs:   milliseconds(stats.OutputElapsed),
+		Throughput: stats.Throughput(),
+	}
+}
+
+// milliseconds 将时间转换为毫秒
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// writeStats 以文本格式输出统计信息，未启用统计时不输出
+func writeStats(w io.Writer, stats *Stats) {
+	if stats == nil {
+		return
+	}
+
+	fmt.Fprintln(w, i18n.T("stats.title"))
+	fmt.Fprintln(w, i18n.T("stats.dirs", stats.DirsVisited))
+	fmt.Fprintln(w, i18n.T("stats.considered", stats.FilesConsidered))
+	fmt.Fprintln(w, i18n.T("stats.searched", stats.FilesSearched))
+	if stats.SearchElapsed > 0 {
+		fmt.Fprintln(w, i18n.T("stats.bytes", utils.FormatSize(stats.BytesRead), stats.Throughput()))
+	}
+
+	skipped := make([]string, len(skipReasons))
+	for i, reason := range skipReasons {
+		skipped[i] = fmt.Sprintf("%s %d", i18n.T("stats.skip."+reason), stats.Skipped[reason])
+	}
+	fmt.Fprintln(w, i18n.T("stats.skipped", strings.Join(skipped, ", ")))
+
+	var phases []string
+	if stats.CountElapsed > 0 {
+		phases = append(phases, formatPhase("count", stats.CountElapsed))
+	}
+	phases = append(phases, formatPhase("walk", stats.WalkElapsed))
+	if stats.SearchElapsed > 0 {
+		phases = append(phases, formatPhase("search", stats.SearchElapsed))
+	}
+	phases = append(phases, formatPhase("output", stats.OutputElapsed))
+	fmt.Fprintln(w, i18n.T("stats.elapsed", strings.Join(phases, ", ")))
+}
+
+// formatPhase 格式化一个阶段的名称和耗时，耗时保留到微秒
+func formatPhase(phase string, d time.Duration) string {
+	return i18n.T("stats.phase."+phase) + " " + d.Round(time.Microsecond).String()
+}
@@ -0,0 +1,209 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/i18n"
+	"github.com/Lingbou/go-search-tools/internal/matcher"
+	"github.com/Lingbou/go-search-tools/internal/utils"
+)
+
+// TemplateData 是 --format 模板中可用的数据
+//
+// 内容搜索时每个匹配行执行一次模板；文件名搜索以及计数和文件列表模式下
+// 每个文件执行一次模板，此时与行相关的字段为零值。
+type TemplateData struct {
+	Path    string      // 按 --path-style 显示的路径
+	RelPath string      // 相对于搜索路径的路径
+	AbsPath string      // 绝对路径
+	Name    string      // 文件名
+	Size    int64       // 文件大小（字节）
+	Mode    os.FileMode // 文件权限和类型
+	ModTime time.Time   // 修改时间
+	IsDir   bool        // 是否为目录
+
+	Line       int      // 行号，从 1 开始
+	Column     int      // 第一处匹配的列号，从 1 开始
+	Offset     int64    // 第一处匹配在文件中的字节偏移
+	Text       string   // 匹配所在行的内容；启用 --replace 时为替换后的内容，启用 -o 时为匹配或替换文本
+	Match      string   // 第一处匹配的文本
+	Matches    []string // 该行中所有匹配的文本
+	Submatches []string // 第一处匹配的捕获组，下标 0 为整个匹配，未参与匹配的组为空字符串
+	Pattern    string   // 第一处匹配对应的搜索模式，启用 -o 时为该处匹配的模式
+
+	FileMatches int // 文件中匹配的行数
+}
+
+// 模板中可用的辅助函数
+var templateFuncs = template.FuncMap{
+	"formatSize": utils.FormatSize,
+	"base":       filepath.Base,
+	"dir":        filepath.Dir,
+	"ext":        filepath.Ext,
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"trim":       strings.TrimSpace,
+}
+
+// TemplatePrinter 使用用户定义的 text/template 模板输出结果
+type TemplatePrinter struct {
+	Config *config.SearchConfig
+
+	tmpl *template.Template
+	w    io.Writer
+	err  error // 第一次执行模板出错时的错误
+}
+
+// NewTemplatePrinter 解析 cfg.Format 并创建模板输出器
+func NewTemplatePrinter(cfg *config.SearchConfig, w io.Writer) (*TemplatePrinter, error) {
+	tmpl, err := template.New("format").Funcs(templateFuncs).Parse(cfg.Format)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("output.parse_template"), err)
+	}
+
+	return &TemplatePrinter{
+		Config: cfg,
+		tmpl:   tmpl,
+		w:      w,
+	}, nil
+}
+
+// PrintFile 输出文件名搜索匹配到的文件
+func (p *TemplatePrinter) PrintFile(path string, info os.FileInfo) {
+	p.execute(p.fileData(path, info, nil))
+}
+
+// PrintResult 输出内容搜索中单个文件的匹配结果
+func (p *TemplatePrinter) PrintResult(result *FileResult) {
+	cfg := p.Config
+
+	if !cfg.LineOutput() {
+		if cfg.FilesWithoutMatch == (len(result.Matches) == 0) {
+			p.execute(p.fileData(result.Path, result.Info, result.Matches))
+		}
+		return
+	}
+
+	for _, m := range result.Matches {
+		data := p.fileData(result.Path, result.Info, result.Matches)
+		fillLineData(&data, m)
+
+		// 只输出匹配文本时，每处匹配执行一次模板
+		if cfg.OnlyMatching {
+			for _, span := range m.Spans {
+				part, ok := onlyMatchingPart(m, span, cfg.ReplaceEnabled)
+				if !ok {
+					continue
+				}
+				data.Column = part.Column
+				data.Offset = part.Offset
+				data.Text = part.Text
+				data.Match = m.Text[span.Start:span.End]
+				data.Submatches = submatchTexts(m.Text, span)
+				data.Pattern = span.Pattern
+				p.execute(data)
+			}
+			continue
+		}
+
+		if cfg.ReplaceEnabled {
+			data.Text = replaceLine(m).Text
+		}
+		p.execute(data)
+	}
+}
+
+// PrintSummary 模板输出通常交给其他程序处理，不输出汇总信息
+// 统计信息输出到标准错误
+func (p *TemplatePrinter) PrintSummary(summary *Summary) {
+	writeStats(os.Stderr, summary.Stats)
+}
+
+// execute 执行模板，每次输出以换行结尾，使用 NUL 分隔时以 NUL 结尾
+func (p *TemplatePrinter) execute(data TemplateData) {
+	var b strings.Builder
+	if err := p.tmpl.Execute(&b, data); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("output.execute_template", data.Path, err))
+		if p.err == nil {
+			p.err = err
+		}
+		return
+	}
+
+	if p.Config.NullSeparator {
+		b.WriteByte(0)
+	} else {
+		b.WriteByte('\n')
+	}
+	io.WriteString(p.w, b.String())
+}
+
+// Err 返回第一次执行模板出错时的错误
+func (p *TemplatePrinter) Err() error {
+	return p.err
+}
+
+// fileData 创建包含文件级别字段的模板数据
+func (p *TemplatePrinter) fileData(path string, info os.FileInfo, matches []matcher.Match) TemplateData {
+	data := TemplateData{
+		Path:        DisplayPath(p.Config, path),
+		Name:        filepath.Base(path),
+		FileMatches: len(matches),
+	}
+
+	data.RelPath, _ = filepath.Rel(p.Config.SearchPath, path)
+	if data.RelPath == "" || data.RelPath == "." {
+		data.RelPath = data.Name
+	}
+	data.AbsPath, _ = filepath.Abs(path)
+
+	if info != nil {
+		data.Size = info.Size()
+		data.Mode = info.Mode()
+		data.ModTime = info.ModTime()
+		data.IsDir = info.IsDir()
+	}
+	return data
+}
+
+// fillLineData 填充与匹配行相关的模板字段
+func fillLineData(data *TemplateData, m matcher.Match) {
+	data.Line = m.Line
+	data.Column = m.Column
+	data.Offset = m.Offset
+	data.Text = m.Text
+
+	for _, span := range m.Spans {
+		data.Matches = append(data.Matches, m.Text[span.Start:span.End])
+	}
+	if len(data.Matches) > 0 {
+		data.Match = data.Matches[0]
+	}
+	if len(m.Spans) > 0 {
+		data.Submatches = submatchTexts(m.Text, m.Spans[0])
+		data.Pattern = m.Spans[0].Pattern
+	}
+}
+
+// submatchTexts 返回一处匹配中各捕获组的文本
+func submatchTexts(text string, span matcher.Span) []string {
+	if len(span.Groups) == 0 {
+		return []string{text[span.Start:span.End]}
+	}
+
+	groups := make([]string, len(span.Groups)/2)
+	for i := range groups {
+		start, end := span.Groups[2*i], span.Groups[2*i+1]
+		if start >= 0 && end >= 0 {
+			groups[i] = text[start:end]
+		}
+	}
+	return groups
+}
@@ -0,0 +1,189 @@
+package output
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+
+	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/i18n"
+	"github.com/Lingbou/go-search-tools/internal/matcher"
+	"github.com/Lingbou/go-search-tools/internal/utils"
+)
+
+// TextPrinter 以人类可读的文本格式输出结果
+type TextPrinter struct {
+	Config *config.SearchConfig
+
+	// 是否已经输出过内容搜索结果，用于决定是否打印分隔符
+	printed bool
+
+	// 启用超链接时使用的主机名
+	host string
+}
+
+// NewTextPrinter 创建一个新的文本输出器
+func NewTextPrinter(cfg *config.SearchConfig) *TextPrinter {
+	p := &TextPrinter{
+		Config: cfg,
+	}
+	if cfg.Hyperlink {
+		p.host = hostname()
+	}
+	return p
+}
+
+// PrintFile 输出文件名搜索匹配到的文件
+func (p *TextPrinter) PrintFile(path string, info os.FileInfo) {
+	display := p.link(path, DisplayPath(p.Config, path), 0, 0)
+
+	// 使用 NUL 分隔时只输出路径，便于交给 xargs -0 处理
+	if p.Config.NullSeparator {
+		p.printPath(display)
+		return
+	}
+
+	utils.PrintMatch(display, info, p.Config.ColorOutput, p.Config.ShowMetadata)
+}
+
+// printPath 只输出路径，以换行或 NUL 结尾
+func (p *TextPrinter) printPath(path string) {
+	if p.Config.NullSeparator {
+		fmt.Print(path, "\x00")
+		return
+	}
+	utils.PrintMatch(path, nil, p.Config.ColorOutput, false)
+}
+
+// PrintResult 输出内容搜索中单个文件的匹配结果
+func (p *TextPrinter) PrintResult(result *FileResult) {
+	cfg := p.Config
+	useColor := cfg.ColorOutput
+	display := DisplayPath(cfg, result.Path)
+	path := p.link(result.Path, display, 0, 0)
+
+	// 计数和文件列表模式只输出文件级别的结果
+	switch {
+	case cfg.FilesWithoutMatch:
+		if len(result.Matches) == 0 {
+			p.printPath(path)
+		}
+		return
+	case len(result.Matches) == 0:
+		return
+	case cfg.FilesWithMatches:
+		p.printPath(path)
+		return
+	case cfg.CountLines:
+		utils.PrintCount(path, p.pathEnd(":"), len(result.Matches), useColor)
+		return
+	case cfg.CountMatches:
+		utils.PrintCount(path, p.pathEnd(":"), CountMatches(result.Matches), useColor)
+		return
+	}
+
+	// 分组输出时每个文件只在开头输出一次路径，文件之间以空行分隔
+	if cfg.Heading {
+		if p.printed {
+			fmt.Println()
+		}
+		utils.PrintHeading(path, useColor)
+	}
+
+	if result.Groups == nil {
+		for _, m := range result.Matches {
+			p.printMatch(result.Path, display, m)
+		}
+		p.printed = true
+		return
+	}
+
+	// 不相邻的分组之间以及文件之间使用 -- 分隔，分组输出时文件之间已有空行
+	for i, group := range result.Groups {
+		if i > 0 || (p.printed && !cfg.Heading) {
+			utils.PrintSeparator(useColor)
+		}
+
+		for _, line := range group {
+			if line.IsContext {
+				path, end := p.linePath(result.Path, display, line.Line, 0, "-")
+				utils.PrintContextLine(path, end, line.Line, line.Text, useColor)
+			} else {
+				p.printMatch(result.Path, display, line.Match)
+			}
+		}
+	}
+	p.printed = true
+}
+
+// printMatch 输出一行匹配，根据配置只输出匹配文本或输出替换后的行
+func (p *TextPrinter) printMatch(path, display string, m matcher.Match) {
+	cfg := p.Config
+	if cfg.OnlyMatching {
+		for _, part := range onlyMatching(m, cfg.ReplaceEnabled) {
+			linked, end := p.linePath(path, display, part.Line, part.Column, ":")
+			utils.PrintLineMatch(linked, end, part, cfg.ColorOutput)
+		}
+		return
+	}
+
+	if cfg.ReplaceEnabled {
+		m = replaceLine(m)
+	}
+	linked, end := p.linePath(path, display, m.Line, m.Column, ":")
+	utils.PrintLineMatch(linked, end, m, cfg.ColorOutput)
+}
+
+// linePath 返回每行结果前输出的路径和分隔符
+// 分组输出时路径已经在标题中输出，行前不再重复
+func (p *TextPrinter) linePath(path, display string, line, column int, sep string) (string, string) {
+	if p.Config.Heading {
+		return "", ""
+	}
+	return p.link(path, display, line, column), p.pathEnd(sep)
+}
+
+// link 启用超链接时将显示的路径包装为 OSC 8 超链接
+// 使用 NUL 分隔时输出交给其他程序处理，不添加超链接
+func (p *TextPrinter) link(path, display string, line, column int) string {
+	if !p.Config.Hyperlink || p.Config.NullSeparator {
+		return display
+	}
+	return utils.Hyperlink(hyperlinkURL(p.Config.HyperlinkFormat, p.host, path, line, column), display)
+}
+
+// pathEnd 返回路径之后的分隔符，使用 NUL 分隔时为 NUL 字符
+func (p *TextPrinter) pathEnd(sep string) string {
+	if p.Config.NullSeparator {
+		return "\x00"
+	}
+	return sep
+}
+
+// PrintSummary 输出搜索结束后的汇总信息
+func (p *TextPrinter) PrintSummary(summary *Summary) {
+	// 使用 NUL 分隔时输出通常交给其他程序处理，不打印摘要，统计信息输出到标准错误
+	if p.Config.NullSeparator {
+		writeStats(os.Stderr, summary.Stats)
+		return
+	}
+	defer writeStats(os.Stdout, summary.Stats)
+
+	switch {
+	case p.Config.FilesWithoutMatch && summary.TimedOut:
+		color.Yellow(i18n.T("summary.unmatched_timeout"), summary.Unmatched)
+	case p.Config.FilesWithoutMatch:
+		color.Green(i18n.T("summary.unmatched"), summary.Unmatched)
+	case summary.TimedOut && summary.Matches > 0:
+		color.Yellow(i18n.T("summary.lines_timeout"), summary.Files, summary.Matches)
+	case summary.TimedOut:
+		color.Yellow(i18n.T("summary.files_timeout"), summary.Files)
+	case summary.Files == 0:
+		color.Yellow(i18n.T("summary.none"))
+	case summary.Matches > 0:
+		color.Green(i18n.T("summary.lines"), summary.Files, summary.Matches)
+	default:
+		color.Green(i18n.T("summary.files"), summary.Files)
+	}
+}
@@ -0,0 +1,77 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Lingbou/go-search-tools/internal/config"
+)
+
+// VimgrepPrinter 以 path:line:col:text 的格式输出，每处匹配一行
+// 可以直接被 Vim/Neovim 的 quickfix 和 Emacs 的 grep-mode 解析
+type VimgrepPrinter struct {
+	Config *config.SearchConfig
+
+	w io.Writer
+}
+
+// NewVimgrepPrinter 创建一个新的 vimgrep 格式输出器
+func NewVimgrepPrinter(cfg *config.SearchConfig, w io.Writer) *VimgrepPrinter {
+	return &VimgrepPrinter{
+		Config: cfg,
+		w:      w,
+	}
+}
+
+// PrintFile 输出文件名搜索匹配到的文件，位置固定为第 1 行第 1 列
+func (p *VimgrepPrinter) PrintFile(path string, info os.FileInfo) {
+	p.printEntry(path, 1, 1, "")
+}
+
+// PrintResult 输出内容搜索中单个文件的匹配结果
+func (p *VimgrepPrinter) PrintResult(result *FileResult) {
+	cfg := p.Config
+
+	// 计数和文件列表模式每个文件输出一行
+	if !cfg.LineOutput() {
+		if cfg.FilesWithoutMatch == (len(result.Matches) == 0) {
+			p.printEntry(result.Path, 1, 1, "")
+		}
+		return
+	}
+
+	for _, m := range result.Matches {
+		text := m.Text
+		if cfg.ReplaceEnabled {
+			text = replaceLine(m).Text
+		}
+
+		// 反向匹配的行没有匹配区间，整行输出一次
+		if len(m.Spans) == 0 {
+			p.printEntry(result.Path, m.Line, m.Column, text)
+			continue
+		}
+		for _, span := range m.Spans {
+			if cfg.OnlyMatching {
+				part, ok := onlyMatchingPart(m, span, cfg.ReplaceEnabled)
+				if !ok {
+					continue
+				}
+				text = part.Text
+			}
+			p.printEntry(result.Path, m.Line, span.Start+1, text)
+		}
+	}
+}
+
+// PrintSummary vimgrep 格式不输出汇总信息，避免被编辑器误解析
+// 统计信息输出到标准错误
+func (p *VimgrepPrinter) PrintSummary(summary *Summary) {
+	writeStats(os.Stderr, summary.Stats)
+}
+
+// printEntry 输出一条 quickfix 记录
+func (p *VimgrepPrinter) printEntry(path string, line, column int, text string) {
+	fmt.Fprintf(p.w, "%s:%d:%d:%s\n", DisplayPath(p.Config, path), line, column, text)
+}
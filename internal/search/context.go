@@ -0,0 +1,22 @@
+package search
+
+import (
+	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/output"
+)
+
+// hasContext 判断是否需要输出上下文行
+// 只输出匹配文本或使用自定义模板时不输出上下文
+func hasContext(cfg *config.SearchConfig) bool {
+	return cfg.LineOutput() && !cfg.OnlyMatching && cfg.Format == "" && (cfg.BeforeContext > 0 || cfg.AfterContext > 0)
+}
+
+// addContext 为文件的匹配结果补充上下文行
+func addContext(result *output.FileResult, before, after int) error {
+	groups, err := output.ContextGroups(result.Path, result.Matches, before, after)
+	if err != nil {
+		return err
+	}
+	result.Groups = groups
+	return nil
+}
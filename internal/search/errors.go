@@ -0,0 +1,71 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"sync"
+
+	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/i18n"
+	"github.com/Lingbou/go-search-tools/internal/matcher"
+	"github.com/Lingbou/go-search-tools/internal/utils"
+)
+
+// ErrFileErrors 搜索已完成，但有文件因为读取错误被跳过
+var ErrFileErrors error = i18n.Error("search.file_errors")
+
+// fileErrors 记录并输出搜索过程中单个文件的错误，可以在多个工作协程中使用
+type fileErrors struct {
+	cfg *config.SearchConfig
+
+	mu    sync.Mutex
+	count int
+}
+
+// newFileErrors 创建文件错误记录器
+func newFileErrors(cfg *config.SearchConfig) *fileErrors {
+	return &fileErrors{cfg: cfg}
+}
+
+// report 记录一个文件的错误，未使用 --no-messages 时输出到标准错误
+// 超时和超过大小限制属于正常的跳过，不视为错误
+func (e *fileErrors) report(path string, err error) {
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return
+	case errors.Is(err, matcher.ErrFileTooLarge):
+		return
+	}
+
+	e.fail()
+	if !e.cfg.NoMessages {
+		utils.PrintError(i18n.T("search.error"), describeError(path, err))
+	}
+}
+
+// fail 记录一个已经输出过的错误，例如遍历目录失败
+func (e *fileErrors) fail() {
+	e.mu.Lock()
+	e.count++
+	e.mu.Unlock()
+}
+
+// err 有文件出错时返回 ErrFileErrors
+func (e *fileErrors) err() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.count > 0 {
+		return ErrFileErrors
+	}
+	return nil
+}
+
+// describeError 返回包含文件路径的错误描述
+func describeError(path string, err error) string {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return err.Error()
+	}
+	return path + ": " + err.Error()
+}
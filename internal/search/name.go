@@ -2,14 +2,20 @@ package search
 
 import (
 	// "fmt"
+	"context"
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/fatih/color"
 	
 	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/i18n"
 	"github.com/Lingbou/go-search-tools/internal/matcher"
+	"github.com/Lingbou/go-search-tools/internal/output"
 	"github.com/Lingbou/go-search-tools/internal/utils"
 	"github.com/Lingbou/go-search-tools/pkg/filter"
 )
@@ -18,6 +24,9 @@ import (
 type NameSearcher struct {
 	Config *config.SearchConfig
 	Filter filter.FileFilter
+	
+	// 接收搜索结果的输出器，为空时根据配置创建
+	Printer output.Printer
 }
 
 // NewNameSearcher 创建一个新的文件名搜索器
@@ -33,34 +42,78 @@ func NewNameSearcher(cfg *config.SearchConfig) *NameSearcher {
 	}
 }
 
-// Search 执行文件名搜索
-func (s *NameSearcher) Search(pattern string) error {
+// Search 执行文件名搜索，返回是否找到了匹配的文件
+// 搜索完成但有目录读取出错时返回 ErrFileErrors
+func (s *NameSearcher) Search(pattern string) (bool, error) {
+	return s.SearchContext(context.Background(), pattern)
+}
+
+// SearchContext 与 Search 相同，ctx 被取消时提前结束搜索
+func (s *NameSearcher) SearchContext(ctx context.Context, pattern string) (bool, error) {
 	// 检查路径是否存在
 	if _, err := os.Stat(s.Config.SearchPath); os.IsNotExist(err) {
-		color.Red("错误: 搜索路径不存在: %s", s.Config.SearchPath)
-		return err
+		utils.PrintError(i18n.T("search.path_not_found"), s.Config.SearchPath)
+		return false, err
 	}
 	
 	// 用于存储匹配的文件
 	var matches []string
 	var mu sync.Mutex
 	
+	// 创建输出器
+	printer := s.Printer
+	if printer == nil {
+		var err error
+		if printer, err = output.NewPrinter(s.Config); err != nil {
+			utils.PrintError(i18n.T("search.error"), err)
+			return false, err
+		}
+	}
+	startTime := time.Now()
+	
+	// 收集统计信息、各阶段耗时和目录读取错误
+	stats := newStatsCollector(s.Config.ShowStats)
+	fileErrs := newFileErrors(s.Config)
+	var elapsed phases
+	
 	// 创建进度跟踪器
-	progress := utils.NewProgressTracker(s.Config.ShowProgress, "搜索中")
+	progress := newProgressTracker(s.Config)
+	defer progress.Finish()
 	
 	// 计算文件总数用于进度条
 	if s.Config.ShowProgress {
 		totalFiles := utils.CountFiles(s.Config.SearchPath, s.Config.IncludeExts, s.Config.ExcludeExts)
+		elapsed.count = time.Since(startTime)
 		if totalFiles == 0 {
-			color.Yellow("没有找到文件")
-			return nil
+			if !s.Config.Quiet {
+				color.Yellow(i18n.T("search.no_files"))
+			}
+			return false, nil
 		}
 		progress.SetTotal(totalFiles)
 	}
 	
+	// 遍历本身是串行的，只有指定排序方式时才需要收集结果
+	var order *resultOrder
+	if s.Config.SortBy != config.SortWalk && s.Config.SortBy != config.SortNone {
+		order = newResultOrder(s.Config)
+	}
+	
 	// 递归搜索文件
+	walkStart := time.Now()
 	err := filepath.Walk(s.Config.SearchPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
+			// 跳过没有权限读取的文件和目录，继续搜索其他文件
+			if errors.Is(err, fs.ErrPermission) {
+				stats.skip(output.SkipPermission)
+				fileErrs.report(path, err)
+				return nil
+			}
+			return err
+		}
+		
+		// 检查搜索是否已被取消
+		if err := ctx.Err(); err != nil {
 			return err
 		}
 		
@@ -72,40 +125,81 @@ func (s *NameSearcher) Search(pattern string) error {
 		// 应用过滤器
 		if !s.Filter.ShouldInclude(path, info) {
 			if info.IsDir() && path != s.Config.SearchPath {
+				stats.skip(output.SkipExcludedDir)
 				return filepath.SkipDir
 			}
+			if !info.IsDir() {
+				stats.file()
+				stats.skip(output.SkipExtension)
+			}
 			return nil
 		}
 		
 		// 对于目录，只检查过滤条件
+		progress.Walked(path, info)
 		if info.IsDir() {
+			stats.dir()
 			return nil
 		}
 		
-		// 文件名匹配
+		// 文件名搜索不读取文件内容
+		stats.file()
+		stats.searched(0)
+		
+		// 文件名匹配，进度事件中的匹配数为匹配的文件数
+		matched := 0
 		if matcher.MatchPattern(info.Name(), pattern, s.Config.IgnoreCase) {
+			matched = 1
+		}
+		progress.Searched(0, matched)
+		if matched > 0 {
 			mu.Lock()
 			matches = append(matches, path)
 			mu.Unlock()
 			
-			// 打印匹配结果
-			utils.PrintMatch(path, info, s.Config.ColorOutput)
+			// 打印匹配结果，需要排序时等遍历结束后统一输出
+			if order != nil {
+				order.add(&output.FileResult{Index: len(matches), Path: path, Info: info})
+			} else {
+				printStart := time.Now()
+				printer.PrintFile(path, info)
+				elapsed.output += time.Since(printStart)
+			}
+			
+			// 使用 --quiet 时找到第一个文件即可结束搜索
+			if s.Config.Quiet {
+				return filepath.SkipAll
+			}
 		}
 		
 		return nil
 	})
+	elapsed.walk = time.Since(walkStart)
 	
-	if err != nil {
-		color.Red("搜索过程中出错: %v", err)
-		return err
+	if err != nil && err != ctx.Err() {
+		utils.PrintError(i18n.T("search.walk_error"), err)
+		return len(matches) > 0, err
 	}
 	
-	// 打印结果摘要
-	if len(matches) == 0 {
-		color.Yellow("没有找到匹配的文件")
-	} else {
-		color.Green("共找到 %d 个匹配的文件", len(matches))
+	if order != nil {
+		printStart := time.Now()
+		for _, result := range order.finish() {
+			printer.PrintFile(result.Path, result.Info)
+		}
+		elapsed.output += time.Since(printStart)
 	}
 	
-	return nil
+	// 打印结果摘要
+	printer.PrintSummary(&output.Summary{
+		Pattern: pattern,
+		Files:   len(matches),
+		Elapsed: time.Since(startTime),
+		Stats:   stats.result(elapsed),
+	})
+	
+	// 写入报告或执行模板出错时同样视为出错
+	if err := output.PrinterErr(printer); err != nil {
+		return len(matches) > 0, err
+	}
+	return len(matches) > 0, fileErrs.err()
 }
\ No newline at end of file
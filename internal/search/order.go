@@ -0,0 +1,119 @@
+package search
+
+import (
+	"sort"
+
+	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/output"
+)
+
+// resultOrder 决定搜索结果的输出顺序
+//
+// 默认按遍历顺序输出：并行工作协程完成的结果先放入重排缓冲区，
+// 一旦前面的文件都已完成就立即输出，不需要等待整个搜索结束。
+// 指定排序字段时收集全部结果，搜索结束后排序输出。
+type resultOrder struct {
+	sortBy  string
+	reverse bool
+
+	next    int                        // 下一个应输出结果的遍历序号
+	pending map[int]*output.FileResult // 等待前面的结果完成的缓冲区
+	all     []*output.FileResult       // 需要排序时收集的全部结果
+}
+
+// newResultOrder 根据配置创建结果排序器
+func newResultOrder(cfg *config.SearchConfig) *resultOrder {
+	return &resultOrder{
+		sortBy:  cfg.SortBy,
+		reverse: cfg.SortReverse,
+		pending: make(map[int]*output.FileResult),
+	}
+}
+
+// add 加入一个结果，返回现在可以输出的结果
+func (o *resultOrder) add(result *output.FileResult) []*output.FileResult {
+	switch o.sortBy {
+	case config.SortNone:
+		return []*output.FileResult{result}
+	case config.SortWalk:
+		o.pending[result.Index] = result
+
+		var ready []*output.FileResult
+		for {
+			next, ok := o.pending[o.next]
+			if !ok {
+				break
+			}
+			delete(o.pending, o.next)
+			ready = append(ready, next)
+			o.next++
+		}
+		return ready
+	default:
+		o.all = append(o.all, result)
+		return nil
+	}
+}
+
+// finish 搜索结束后返回剩余的结果
+// 超时等情况下遍历序号可能不连续，缓冲区中的结果按序号输出
+func (o *resultOrder) finish() []*output.FileResult {
+	if o.sortBy == config.SortWalk {
+		for _, result := range o.pending {
+			o.all = append(o.all, result)
+		}
+		o.pending = make(map[int]*output.FileResult)
+	}
+
+	results := o.all
+	o.all = nil
+	sort.SliceStable(results, func(i, j int) bool {
+		return o.less(results[i], results[j])
+	})
+	return results
+}
+
+// less 按排序字段比较两个结果，字段相同时按遍历顺序
+func (o *resultOrder) less(a, b *output.FileResult) bool {
+	var cmp int
+	switch o.sortBy {
+	case config.SortPath:
+		cmp = compareStrings(a.Path, b.Path)
+	case config.SortSize:
+		cmp = compareInts(a.Info.Size(), b.Info.Size())
+	case config.SortModTime:
+		cmp = a.Info.ModTime().Compare(b.Info.ModTime())
+	case config.SortMatches:
+		cmp = compareInts(int64(len(a.Matches)), int64(len(b.Matches)))
+	}
+
+	if o.reverse {
+		cmp = -cmp
+	}
+	if cmp == 0 {
+		return a.Index < b.Index
+	}
+	return cmp < 0
+}
+
+// compareStrings 比较两个字符串
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareInts 比较两个整数
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
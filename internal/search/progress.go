@@ -0,0 +1,15 @@
+package search
+
+import (
+	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/i18n"
+	"github.com/Lingbou/go-search-tools/internal/utils"
+)
+
+// newProgressTracker 根据 --progress 创建进度条或 JSON 进度事件的跟踪器
+func newProgressTracker(cfg *config.SearchConfig) *utils.ProgressTracker {
+	if cfg.Progress == config.ProgressJSON {
+		return utils.NewJSONProgressTracker(utils.ProgressOutput(cfg.ProgressFD))
+	}
+	return utils.NewProgressTracker(cfg.ShowProgress, i18n.T("search.progress"))
+}
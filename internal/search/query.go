@@ -0,0 +1,35 @@
+package search
+
+import (
+	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/matcher"
+)
+
+// NewQuerySearcher 创建一个按布尔查询搜索的内容搜索器
+// regex 为 true 时查询中的每个条件是正则表达式，否则是固定字符串，查询无效时返回错误
+func NewQuerySearcher(cfg *config.SearchConfig, query string, regex bool) (*Searcher, error) {
+	newMatcher := func(pattern string) (matcher.Matcher, error) {
+		contentMatcher := matcher.NewContentMatcher([]string{pattern}, cfg.IgnoreCase)
+		contentMatcher.SetBoundary(boundary(cfg))
+		return contentMatcher, nil
+	}
+	if regex {
+		newMatcher = func(pattern string) (matcher.Matcher, error) {
+			regexMatcher, err := matcher.NewRegexMatcher([]string{pattern}, cfg.IgnoreCase)
+			if err != nil {
+				return nil, err
+			}
+			regexMatcher.SetBoundary(boundary(cfg))
+			if cfg.ReplaceEnabled {
+				regexMatcher.SetReplacement(cfg.Replace)
+			}
+			return regexMatcher, nil
+		}
+	}
+
+	queryMatcher, err := matcher.NewQueryMatcher(query, cfg.QueryPerLine, newMatcher)
+	if err != nil {
+		return nil, err
+	}
+	return NewSearcher(cfg, queryMatcher), nil
+}
@@ -0,0 +1,297 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	// "fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	
+	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/i18n"
+	"github.com/Lingbou/go-search-tools/internal/matcher"
+	"github.com/Lingbou/go-search-tools/internal/output"
+	"github.com/Lingbou/go-search-tools/internal/utils"
+	"github.com/Lingbou/go-search-tools/pkg/filter"
+)
+
+// Searcher 文件内容搜索器，内容搜索和正则表达式搜索共用同一个搜索流程，
+// 遍历目录、并行匹配、排序和输出都与匹配方式无关
+type Searcher struct {
+	Config  *config.SearchConfig
+	Filter  filter.FileFilter
+	Matcher matcher.Matcher
+	
+	// 接收搜索结果的输出器，为空时根据配置创建
+	Printer output.Printer
+}
+
+// NewSearcher 创建一个使用指定匹配器的内容搜索器
+// 启用反向匹配时逐行取反；文件列表模式只关心文件中有没有匹配，
+// 由调用者交换 --files-with-matches 和 --files-without-match 实现取反，匹配器保持不变
+func NewSearcher(cfg *config.SearchConfig, m matcher.Matcher) *Searcher {
+	if cfg.InvertMatch && !cfg.FilesWithMatches && !cfg.FilesWithoutMatch {
+		m = matcher.NewInvertMatcher(m)
+	}
+	
+	// 创建过滤器
+	dirFilter := filter.NewDirectoryFilter(cfg.SearchPath, cfg.ExcludeDirs, cfg.MaxDepth)
+	extFilter := filter.NewExtensionFilter(cfg.IncludeExts, cfg.ExcludeExts)
+	compositeFilter := filter.NewCompositeFilter(dirFilter, extFilter)
+	
+	return &Searcher{
+		Config:  cfg,
+		Filter:  compositeFilter,
+		Matcher: m,
+	}
+}
+
+// Search 执行搜索，返回是否找到了结果
+// 搜索完成但有文件读取出错时返回 ErrFileErrors
+func (s *Searcher) Search() (bool, error) {
+	return s.SearchContext(context.Background())
+}
+
+// SearchContext 与 Search 相同，ctx 被取消时提前结束搜索
+func (s *Searcher) SearchContext(parent context.Context) (bool, error) {
+	// 检查路径是否存在
+	if _, err := os.Stat(s.Config.SearchPath); os.IsNotExist(err) {
+		utils.PrintError(i18n.T("search.path_not_found"), s.Config.SearchPath)
+		return false, err
+	}
+	
+	// 创建上下文用于超时控制，使用 --quiet 时找到结果后也会取消搜索
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+	if s.Config.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, s.Config.Timeout)
+		defer cancel()
+	}
+	
+	// 用于存储匹配的文件
+	var matches []string
+	var mu sync.Mutex
+	
+	// 创建输出器
+	printer := s.Printer
+	if printer == nil {
+		var err error
+		if printer, err = output.NewPrinter(s.Config); err != nil {
+			utils.PrintError(i18n.T("search.error"), err)
+			return false, err
+		}
+	}
+	startTime := time.Now()
+	
+	// 收集统计信息、各阶段耗时和文件错误
+	stats := newStatsCollector(s.Config.ShowStats)
+	fileErrs := newFileErrors(s.Config)
+	var elapsed phases
+	
+	// 创建进度跟踪器
+	progress := newProgressTracker(s.Config)
+	defer progress.Finish()
+	
+	// 计算文件总数用于进度条
+	if s.Config.ShowProgress {
+		totalFiles := utils.CountFiles(s.Config.SearchPath, s.Config.IncludeExts, s.Config.ExcludeExts)
+		elapsed.count = time.Since(startTime)
+		if totalFiles == 0 {
+			if !s.Config.Quiet {
+				color.Yellow(i18n.T("search.no_files"))
+			}
+			return false, nil
+		}
+		progress.SetTotal(totalFiles)
+	}
+	
+	searchStart := time.Now()
+	
+	// 创建文件通道
+	filesCh := make(chan *output.FileResult)
+	resultsCh := make(chan *output.FileResult)
+	
+	// 启动工作协程
+	var wg sync.WaitGroup
+	for i := 0; i < s.Config.NumWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for result := range filesCh {
+				// 检查是否超时
+				select {
+				case <-ctx.Done():
+					return
+				default:
+					// 搜索文件内容
+					fileMatches, err := s.Matcher.MatchFile(ctx, result.Path)
+					if err == nil {
+						result.Matches = fileMatches
+						
+						// 补充上下文行
+						if len(fileMatches) > 0 && hasContext(s.Config) {
+							err = addContext(result, s.Config.BeforeContext, s.Config.AfterContext)
+						}
+					}
+					
+					// 出错和没有匹配的文件也需要返回，前者用于保持输出顺序，
+					// 后者用于 --files-without-match
+					result.Err = err
+					if err != nil {
+						stats.skipError(err)
+					} else {
+						stats.searched(result.Info.Size())
+						progress.Searched(result.Info.Size(), len(result.Matches))
+					}
+					resultsCh <- result
+					
+					// 更新进度条
+					if s.Config.ShowProgress {
+						progress.Increment()
+					}
+				}
+			}
+		}()
+	}
+	
+	// 收集结果的协程
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+	
+	// 遍历文件并发送到通道
+	// 超时或 --quiet 取消搜索时工作协程会先于遍历结束，walkDone 用于等待遍历协程退出后再读取遍历耗时
+	walkDone := make(chan struct{})
+	go func() {
+		defer close(walkDone)
+		defer close(filesCh)
+		
+		index := 0
+		err := filepath.Walk(s.Config.SearchPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				// 跳过没有权限读取的文件和目录，继续搜索其他文件
+				if errors.Is(err, fs.ErrPermission) {
+					stats.skip(output.SkipPermission)
+					fileErrs.report(path, err)
+					return nil
+				}
+				return err
+			}
+			
+			// 检查是否超时
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				// 应用过滤器
+				if !s.Filter.ShouldInclude(path, info) {
+					if info.IsDir() && path != s.Config.SearchPath {
+						stats.skip(output.SkipExcludedDir)
+						return filepath.SkipDir
+					}
+					if !info.IsDir() {
+						stats.file()
+						stats.skip(output.SkipExtension)
+					}
+					return nil
+				}
+				
+				// 对于目录，只检查过滤条件
+				progress.Walked(path, info)
+				if info.IsDir() {
+					stats.dir()
+					return nil
+				}
+				stats.file()
+				
+				// 发送文件路径到通道，超时或取消后工作协程不再接收
+				select {
+				case filesCh <- &output.FileResult{Index: index, Path: path, Info: info}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				index++
+				
+				return nil
+			}
+		})
+		elapsed.walk = time.Since(searchStart)
+		
+		if err != nil && err != ctx.Err() {
+			utils.PrintError(i18n.T("search.walk_error"), err)
+			fileErrs.fail()
+		}
+	}()
+	
+	// 处理结果
+	matchedLines := 0
+	unmatched := 0
+	
+	// 是否找到了结果，--files-without-match 时为是否有不包含匹配的文件
+	found := func() bool {
+		if s.Config.FilesWithoutMatch {
+			return unmatched > 0
+		}
+		return len(matches) > 0
+	}
+	handle := func(results []*output.FileResult) {
+		for _, result := range results {
+			// 读取出错的文件不计入结果
+			if result.Err != nil {
+				fileErrs.report(result.Path, result.Err)
+				continue
+			}
+			
+			if len(result.Matches) > 0 {
+				mu.Lock()
+				matches = append(matches, result.Path)
+				mu.Unlock()
+				matchedLines += len(result.Matches)
+			} else {
+				unmatched++
+			}
+			
+			// 使用 --quiet 时找到第一个结果即可结束搜索
+			if s.Config.Quiet && found() {
+				cancel()
+			}
+			
+			// 打印匹配结果
+			printStart := time.Now()
+			printer.PrintResult(result)
+			elapsed.output += time.Since(printStart)
+		}
+	}
+	
+	// 按遍历顺序或指定的排序方式输出结果
+	order := newResultOrder(s.Config)
+	for result := range resultsCh {
+		handle(order.add(result))
+	}
+	elapsed.search = time.Since(searchStart)
+	handle(order.finish())
+	<-walkDone
+	
+	// 打印结果摘要
+	printer.PrintSummary(&output.Summary{
+		Pattern:   s.Matcher.String(),
+		Files:     len(matches),
+		Matches:   matchedLines,
+		Unmatched: unmatched,
+		Elapsed:   time.Since(startTime),
+		TimedOut:  errors.Is(ctx.Err(), context.DeadlineExceeded),
+		Stats:     stats.result(elapsed),
+	})
+	
+	// 写入报告或执行模板出错时同样视为出错
+	if err := output.PrinterErr(printer); err != nil {
+		return found(), err
+	}
+	return found(), fileErrs.err()
+}
\ No newline at end of file
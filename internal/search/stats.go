@@ -0,0 +1,96 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"sync"
+	"time"
+
+	"github.com/Lingbou/go-search-tools/internal/matcher"
+	"github.com/Lingbou/go-search-tools/internal/output"
+)
+
+// statsCollector 在遍历和搜索过程中收集统计信息，可以在多个工作协程中使用
+type statsCollector struct {
+	enabled bool
+
+	mu    sync.Mutex
+	stats output.Stats
+}
+
+// newStatsCollector 创建统计信息收集器
+func newStatsCollector(enabled bool) *statsCollector {
+	return &statsCollector{
+		enabled: enabled,
+		stats:   output.Stats{Skipped: make(map[string]int)},
+	}
+}
+
+// dir 记录访问了一个目录
+func (c *statsCollector) dir() {
+	c.mu.Lock()
+	c.stats.DirsVisited++
+	c.mu.Unlock()
+}
+
+// file 记录遍历到一个文件
+func (c *statsCollector) file() {
+	c.mu.Lock()
+	c.stats.FilesConsidered++
+	c.mu.Unlock()
+}
+
+// searched 记录搜索了一个文件及读取的字节数
+func (c *statsCollector) searched(bytes int64) {
+	c.mu.Lock()
+	c.stats.FilesSearched++
+	c.stats.BytesRead += bytes
+	c.mu.Unlock()
+}
+
+// skip 记录因指定原因跳过了一个文件或目录
+func (c *statsCollector) skip(reason string) {
+	c.mu.Lock()
+	c.stats.Skipped[reason]++
+	c.mu.Unlock()
+}
+
+// skipError 根据错误类型记录跳过原因
+// 超时导致的错误不计入，超时已经在汇总信息中说明
+func (c *statsCollector) skipError(err error) {
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+	case errors.Is(err, matcher.ErrFileTooLarge):
+		c.skip(output.SkipTooLarge)
+	case errors.Is(err, matcher.ErrScanFailed):
+		c.skip(output.SkipScanError)
+	case errors.Is(err, fs.ErrPermission):
+		c.skip(output.SkipPermission)
+	default:
+		c.skip(output.SkipOther)
+	}
+}
+
+// phases 搜索各阶段的耗时
+type phases struct {
+	count  time.Duration
+	walk   time.Duration
+	search time.Duration
+	output time.Duration
+}
+
+// result 返回收集的统计信息并填入各阶段耗时，未启用统计时返回 nil
+func (c *statsCollector) result(p phases) *output.Stats {
+	if !c.enabled {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.CountElapsed = p.count
+	stats.WalkElapsed = p.walk
+	stats.SearchElapsed = p.search
+	stats.OutputElapsed = p.output
+	return &stats
+}
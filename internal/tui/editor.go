@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultEditor 没有设置 $VISUAL 和 $EDITOR 时使用的编辑器
+const defaultEditor = "vi"
+
+// OpenEditor 使用 $VISUAL 或 $EDITOR 打开选中的文件，并跳转到匹配的行
+// 编辑器命令可以包含参数，例如 EDITOR="code --wait"
+func OpenEditor(sel *Selection) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = defaultEditor
+	}
+
+	// vi、vim、nano、emacs 等编辑器都支持 +行号
+	args := strings.Fields(editor)
+	if sel.Line > 0 {
+		args = append(args, fmt.Sprintf("+%d", sel.Line))
+	}
+	args = append(args, sel.Path)
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	// 标准输入或输出被重定向时，编辑器仍然需要使用终端
+	if term, err := openTerminal(); err == nil && term.tty {
+		defer term.in.Close()
+		cmd.Stdin = term.in
+		cmd.Stdout = term.out
+	}
+	return cmd.Run()
+}
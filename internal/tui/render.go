@@ -0,0 +1,347 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+
+	"github.com/Lingbou/go-search-tools/internal/i18n"
+	"github.com/Lingbou/go-search-tools/internal/matcher"
+)
+
+// 终端样式的转义序列
+const (
+	styleReset   = "\x1b[0m"
+	styleReverse = "\x1b[7m"
+	styleBold    = "\x1b[1m"
+	styleDim     = "\x1b[2m"
+	styleMatch   = "\x1b[1;31m"
+	stylePath    = "\x1b[35m"
+	styleLineNum = "\x1b[32m"
+	stylePrompt  = "\x1b[1;36m"
+	styleError   = "\x1b[31m"
+)
+
+// 预览时最多读取的文件大小
+const maxPreviewSize = 1 << 20
+
+// segment 一段使用相同样式的文本
+type segment struct {
+	text  string
+	style string
+}
+
+// screen 一帧界面的内容，按行拼接后一次写入终端以避免闪烁
+type screen struct {
+	b      strings.Builder
+	width  int
+	color  bool
+	row    int
+	cursor int // 输入框中光标所在的列
+}
+
+// line 输出一行，超出终端宽度的部分被截断
+func (s *screen) line(segs ...segment) {
+	s.draw(segs)
+	s.b.WriteString("\x1b[K")
+	s.row++
+}
+
+// draw 在当前行输出各个片段，返回剩余的宽度
+func (s *screen) draw(segs []segment) int {
+	fmt.Fprintf(&s.b, "\x1b[%d;1H", s.row+1)
+	width := s.width
+	for _, seg := range segs {
+		if width <= 0 {
+			break
+		}
+		text, w := truncate(clean(seg.text), width)
+		width -= w
+		if seg.style != "" && (s.color || seg.style == styleReverse) {
+			s.b.WriteString(seg.style + text + styleReset)
+		} else {
+			s.b.WriteString(text)
+		}
+	}
+	return width
+}
+
+// fill 在选中的行中用反色填满整行
+func (s *screen) fill(segs []segment, selected bool) {
+	if !selected {
+		s.line(segs...)
+		return
+	}
+
+	for i := range segs {
+		if s.color && segs[i].style != "" {
+			segs[i].style = styleReverse + segs[i].style
+		} else {
+			segs[i].style = styleReverse
+		}
+	}
+
+	// 用反色的空格填充剩余部分
+	rest := s.draw(segs)
+	s.b.WriteString(styleReverse + strings.Repeat(" ", rest) + styleReset)
+	s.row++
+}
+
+// clean 将制表符展开为四个空格，其他控制字符替换为 ?，避免破坏界面
+func clean(text string) string {
+	if !strings.ContainsFunc(text, unicode.IsControl) {
+		return text
+	}
+	text = strings.ReplaceAll(text, "\t", "    ")
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return '?'
+		}
+		return r
+	}, text)
+}
+
+// truncate 按显示宽度截断文本，返回截断后的文本和宽度
+// 中文等宽字符占两列，组合字符和表情符号按字形簇处理
+func truncate(text string, width int) (string, int) {
+	total := 0
+	state := -1
+	rest := text
+	for len(rest) > 0 {
+		var w int
+		var cluster string
+		cluster, rest, w, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		if total+w > width {
+			return text[:len(text)-len(rest)-len(cluster)], total
+		}
+		total += w
+	}
+	return text, total
+}
+
+// highlight 将一行文本按匹配区间拆分为普通和高亮的片段
+func highlight(text string, spans []matcher.Span, style string) []segment {
+	var segs []segment
+	last := 0
+	for _, span := range spans {
+		if span.Start < last || span.End > len(text) || span.Start == span.End {
+			continue
+		}
+		segs = append(segs, segment{text: text[last:span.Start], style: style})
+		segs = append(segs, segment{text: text[span.Start:span.End], style: styleMatch})
+		last = span.End
+	}
+	return append(segs, segment{text: text[last:], style: style})
+}
+
+// scroll 匹配位置超出可见宽度时，去掉行首的部分内容，使第一处匹配可见
+func scroll(text string, spans []matcher.Span, width int) (string, []matcher.Span) {
+	if len(spans) == 0 || uniseg.StringWidth(text[:spans[0].Start]) < width/2 {
+		return text, spans
+	}
+
+	// 保留匹配前约 10 个字符，切分位置需要落在字符边界上
+	cut := spans[0].Start
+	for w := 0; cut > 0 && w < 10; w++ {
+		cut--
+		for cut > 0 && !isRuneStart(text[cut]) {
+			cut--
+		}
+	}
+
+	const ellipsis = "…"
+	shift := len(ellipsis) - cut
+	shifted := make([]matcher.Span, len(spans))
+	for i, span := range spans {
+		shifted[i] = matcher.Span{Start: span.Start + shift, End: span.End + shift}
+	}
+	return ellipsis + text[cut:], shifted
+}
+
+// isRuneStart 判断字节是否为 UTF-8 字符的第一个字节
+func isRuneStart(b byte) bool {
+	return b&0xc0 != 0x80
+}
+
+// render 绘制整个界面
+func (u *ui) render() {
+	width, height := u.term.size()
+	s := &screen{width: width, color: u.color}
+
+	u.renderPrompt(s)
+	u.renderStatus(s)
+
+	// 终端较小时不显示预览
+	listHeight := height - 3
+	previewHeight := 0
+	if height >= 12 {
+		listHeight = (height - 4) / 2
+		previewHeight = height - 4 - listHeight
+	}
+	u.listHeight = listHeight
+	u.scrollTo()
+	u.renderList(s, listHeight)
+	if previewHeight > 0 {
+		u.renderPreview(s, previewHeight)
+	}
+
+	s.row = height - 1
+	s.line(segment{text: i18n.T("tui.help"), style: styleDim})
+
+	// 最后将光标移动到输入框
+	fmt.Fprintf(&s.b, "\x1b[1;%dH\x1b[?25h", s.cursor+1)
+	u.term.write("\x1b[?25l" + s.b.String())
+}
+
+// renderPrompt 绘制输入框
+func (u *ui) renderPrompt(s *screen) {
+	prompt := u.query.mode + " > "
+	text := string(u.text)
+	s.cursor = min(uniseg.StringWidth(prompt)+uniseg.StringWidth(clean(text)), s.width-1)
+	s.line(segment{text: prompt, style: stylePrompt}, segment{text: text})
+}
+
+// renderStatus 绘制状态行，包括当前的选项和搜索进度
+func (u *ui) renderStatus(s *screen) {
+	var options []string
+	if u.query.ignoreCase {
+		options = append(options, i18n.T("tui.ignore_case"))
+	}
+	if u.query.filters && u.hasFilters {
+		options = append(options, i18n.T("tui.filters"))
+	}
+
+	var status segment
+	switch {
+	case u.err != nil:
+		status = segment{text: fmt.Sprintf(i18n.T("tui.error"), u.err), style: styleError}
+	case u.query.text == "":
+		status = segment{text: i18n.T("tui.empty"), style: styleDim}
+	case u.searching:
+		status = segment{text: fmt.Sprintf(i18n.T("tui.searching"), len(u.items)), style: styleDim}
+	case u.truncated:
+		status = segment{text: fmt.Sprintf(i18n.T("tui.truncated"), len(u.items)), style: styleDim}
+	default:
+		status = segment{text: fmt.Sprintf(i18n.T("tui.results"), len(u.items), u.elapsed.Round(time.Millisecond)), style: styleDim}
+	}
+
+	segs := []segment{status}
+	for _, option := range options {
+		segs = append(segs, segment{text: "  [" + option + "]", style: styleBold})
+	}
+	s.line(segs...)
+}
+
+// renderList 绘制结果列表
+func (u *ui) renderList(s *screen, height int) {
+	for i := u.offset; i < u.offset+height; i++ {
+		if i >= len(u.items) {
+			s.line()
+			continue
+		}
+
+		it := u.items[i]
+		selected := i == u.selected
+		marker := "  "
+		if selected {
+			marker = "> "
+		}
+
+		segs := []segment{{text: marker, style: styleBold}, {text: it.Display, style: stylePath}}
+		if it.Line > 0 {
+			prefix := fmt.Sprintf(":%d: ", it.Line)
+			text, spans := scroll(it.Text, it.Spans, s.width-uniseg.StringWidth(marker+it.Display+prefix))
+			segs = append(segs, segment{text: prefix, style: styleLineNum})
+			segs = append(segs, highlight(text, spans, "")...)
+		}
+		s.fill(segs, selected)
+	}
+}
+
+// renderPreview 绘制选中结果所在文件的预览，匹配行位于预览区域中间
+func (u *ui) renderPreview(s *screen, height int) {
+	if u.selected >= len(u.items) {
+		s.line(segment{text: strings.Repeat("─", s.width), style: styleDim})
+		for i := 1; i < height; i++ {
+			s.line()
+		}
+		return
+	}
+
+	it := u.items[u.selected]
+	title := it.Display
+	if it.Line > 0 {
+		title = fmt.Sprintf("%s:%d", it.Display, it.Line)
+	}
+	s.line(segment{text: "── " + title + " " + strings.Repeat("─", s.width), style: styleDim})
+	height--
+
+	lines, err := u.preview.lines(it.Path)
+	if err != nil {
+		s.line(segment{text: err.Error(), style: styleDim})
+		height--
+		lines = nil
+	}
+
+	start := 0
+	if it.Line > 0 {
+		start = max(0, min(it.Line-1-height/2, len(lines)-height))
+	}
+	numWidth := len(fmt.Sprint(min(start+height, len(lines))))
+	for i := start; i < start+height; i++ {
+		if i >= len(lines) {
+			s.line()
+			continue
+		}
+
+		number := segment{text: fmt.Sprintf("%*d │ ", numWidth, i+1), style: styleLineNum}
+		if i+1 == it.Line {
+			s.line(append([]segment{number}, highlight(lines[i], it.Spans, styleBold)...)...)
+		} else {
+			s.line(number, segment{text: lines[i]})
+		}
+	}
+}
+
+// previewCache 缓存最近一次预览的文件内容，在同一文件的结果之间移动时不必重新读取
+type previewCache struct {
+	path string
+	data []string
+	err  error
+}
+
+// lines 返回文件的所有行，二进制文件和读取失败时返回错误
+func (c *previewCache) lines(path string) ([]string, error) {
+	if path == c.path {
+		return c.data, c.err
+	}
+	c.path = path
+	c.data, c.err = readPreview(path)
+	return c.data, c.err
+}
+
+// readPreview 读取用于预览的文件内容，只读取开头的 maxPreviewSize 字节
+func readPreview(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxPreviewSize))
+	if err != nil {
+		return nil, err
+	}
+	if bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+		return nil, fmt.Errorf("%s", i18n.T("tui.binary"))
+	}
+
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n"), nil
+}
@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/Lingbou/go-search-tools/internal/config"
+	"github.com/Lingbou/go-search-tools/internal/matcher"
+	"github.com/Lingbou/go-search-tools/internal/output"
+	"github.com/Lingbou/go-search-tools/internal/search"
+)
+
+// 搜索方式
+const (
+	ModeName    = "name"    // 按文件名搜索
+	ModeContent = "content" // 按文件内容搜索
+	ModeRegex   = "regex"   // 使用正则表达式搜索文件内容
+)
+
+// modes 按 Tab 键切换的顺序
+var modes = []string{ModeName, ModeContent, ModeRegex}
+
+// item 结果列表中的一项，文件名搜索时 Line 为 0
+type item struct {
+	Path    string // 文件路径，可以直接用于打开文件
+	Display string // 按 --path-style 显示的路径
+	Line    int
+	Column  int
+	Text    string
+	Spans   []matcher.Span
+}
+
+// event 搜索协程发送给界面的消息
+type event struct {
+	gen     int // 所属搜索的编号，用于丢弃已被取消的搜索的结果
+	items   []item
+	done    bool
+	summary *output.Summary
+	err     error
+}
+
+// collector 实现 output.Printer，将搜索结果发送给界面而不是输出到终端
+type collector struct {
+	ctx    context.Context
+	cfg    *config.SearchConfig
+	gen    int
+	events chan<- event
+}
+
+// send 发送消息，搜索已被取消时直接丢弃
+func (c *collector) send(ev event) {
+	ev.gen = c.gen
+	select {
+	case c.events <- ev:
+	case <-c.ctx.Done():
+	}
+}
+
+// PrintFile 发送文件名搜索匹配到的文件
+func (c *collector) PrintFile(path string, info os.FileInfo) {
+	c.send(event{items: []item{{Path: path, Display: output.DisplayPath(c.cfg, path)}}})
+}
+
+// PrintResult 发送单个文件中的所有匹配行
+func (c *collector) PrintResult(result *output.FileResult) {
+	if len(result.Matches) == 0 {
+		return
+	}
+
+	display := output.DisplayPath(c.cfg, result.Path)
+	items := make([]item, len(result.Matches))
+	for i, m := range result.Matches {
+		items[i] = item{
+			Path:    result.Path,
+			Display: display,
+			Line:    m.Line,
+			Column:  m.Column,
+			Text:    m.Text,
+			Spans:   m.Spans,
+		}
+	}
+	c.send(event{items: items})
+}
+
+// PrintSummary 记录汇总信息，搜索结束后随 done 消息一起发送
+func (c *collector) PrintSummary(summary *output.Summary) {
+	c.send(event{summary: summary})
+}
+
+// query 一次搜索的条件
+type query struct {
+	mode       string
+	text       string
+	ignoreCase bool
+	filters    bool // 是否应用命令行指定的目录和扩展名过滤
+}
+
+// validate 检查搜索条件，正则表达式无效时返回错误
+// 输入时立即检查，不必等到延迟结束、开始搜索时才显示错误
+func (q query) validate() error {
+	if q.mode != ModeRegex {
+		return nil
+	}
+	_, err := regexp.Compile(q.text)
+	return err
+}
+
+// namePattern 返回文件名搜索使用的通配符模式
+// 不包含通配符时按子串匹配，这样输入文件名的一部分即可看到结果
+func (q query) namePattern() string {
+	if strings.ContainsAny(q.text, "*?") {
+		return q.text
+	}
+	return "*" + q.text + "*"
+}
+
+// config 返回本次搜索使用的配置
+func (q query) config(base *config.SearchConfig) *config.SearchConfig {
+	cfg := *base
+	cfg.IgnoreCase = q.ignoreCase
+	if !q.filters {
+		cfg.ExcludeDirs = nil
+		cfg.IncludeExts = nil
+		cfg.ExcludeExts = nil
+	}
+	return &cfg
+}
+
+// run 执行搜索，结果通过 events 发送，结束时发送 done 消息
+func (q query) run(ctx context.Context, base *config.SearchConfig, gen int, events chan<- event) {
+	cfg := q.config(base)
+	printer := &collector{ctx: ctx, cfg: cfg, gen: gen, events: events}
+
+	var err error
+	switch q.mode {
+	case ModeName:
+		searcher := search.NewNameSearcher(cfg)
+		searcher.Printer = printer
+		_, err = searcher.SearchContext(ctx, q.namePattern())
+	case ModeContent:
+		searcher := search.NewContentSearcher(cfg, []string{q.text})
+		searcher.Printer = printer
+		_, err = searcher.SearchContext(ctx)
+	case ModeRegex:
+		var searcher *search.Searcher
+		if searcher, err = search.NewRegexSearcher(cfg, []string{q.text}); err == nil {
+			searcher.Printer = printer
+			_, err = searcher.SearchContext(ctx)
+		}
+	}
+
+	// 个别文件无法读取不影响其他结果，不在界面中作为错误显示
+	if errors.Is(err, search.ErrFileErrors) {
+		err = nil
+	}
+	printer.send(event{done: true, err: err})
+}
@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"os"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+
+	"github.com/Lingbou/go-search-tools/internal/i18n"
+	"github.com/Lingbou/go-search-tools/internal/utils"
+)
+
+// ErrNoTerminal 没有可用的终端，例如标准输入和输出都被重定向且无法打开 /dev/tty
+var ErrNoTerminal error = i18n.Error("tui.no_terminal")
+
+// terminal 界面使用的终端
+// 优先使用 /dev/tty，这样标准输出被重定向时，例如 vim $(gost tui)，界面仍然显示在终端上
+type terminal struct {
+	in    *os.File
+	out   *os.File
+	tty   bool // in 和 out 是否为单独打开的 /dev/tty，结束时需要关闭
+	state *term.State
+}
+
+// openTerminal 打开界面使用的终端
+func openTerminal() (*terminal, error) {
+	if f, err := os.OpenFile("/dev/tty", os.O_RDWR, 0); err == nil {
+		return &terminal{in: f, out: f, tty: true}, nil
+	}
+	if utils.IsTerminal(os.Stdin) && utils.IsTerminal(os.Stdout) {
+		return &terminal{in: os.Stdin, out: os.Stdout}, nil
+	}
+	return nil, ErrNoTerminal
+}
+
+// start 将终端切换到原始模式并使用备用屏幕，退出后恢复原来的屏幕内容
+func (t *terminal) start() error {
+	state, err := term.MakeRaw(int(t.in.Fd()))
+	if err != nil {
+		return err
+	}
+	t.state = state
+	t.write("\x1b[?1049h\x1b[H\x1b[2J")
+	return nil
+}
+
+// stop 恢复终端的原始状态
+func (t *terminal) stop() {
+	t.write("\x1b[?25h\x1b[?1049l")
+	if t.state != nil {
+		term.Restore(int(t.in.Fd()), t.state)
+	}
+	if t.tty {
+		t.in.Close()
+	}
+}
+
+// size 返回终端的列数和行数，无法获取时使用 80x24
+func (t *terminal) size() (int, int) {
+	width, height, err := term.GetSize(int(t.out.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// write 将内容写入终端
+func (t *terminal) write(s string) {
+	t.out.WriteString(s)
+}
+
+// readKeys 持续读取终端输入并解析为按键，读取出错时关闭通道
+func (t *terminal) readKeys(keys chan<- []key) {
+	defer close(keys)
+
+	buf := make([]byte, 256)
+	var pending []byte
+	for {
+		n, err := t.in.Read(buf)
+		if err != nil {
+			return
+		}
+		pending = append(pending, buf[:n]...)
+
+		var parsed []key
+		parsed, pending = parseKeys(pending)
+		if len(parsed) > 0 {
+			keys <- parsed
+		}
+	}
+}
+
+// keyCode 按键类型
+type keyCode int
+
+const (
+	keyRune keyCode = iota // 普通字符
+	keyEnter
+	keyBackspace
+	keyUp
+	keyDown
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyTab
+	keyBackTab
+	keyEsc
+	keyCtrlC
+	keyCtrlF
+	keyCtrlN
+	keyCtrlO
+	keyCtrlP
+	keyCtrlT
+	keyCtrlU
+	keyCtrlW
+)
+
+// key 一次按键
+type key struct {
+	code keyCode
+	r    rune // code 为 keyRune 时输入的字符
+}
+
+// controlKeys 控制字符对应的按键
+var controlKeys = map[byte]keyCode{
+	'\r':   keyEnter,
+	'\n':   keyEnter,
+	'\t':   keyTab,
+	0x7f:   keyBackspace,
+	0x08:   keyBackspace,
+	0x03:   keyCtrlC,
+	0x06:   keyCtrlF,
+	0x0e:   keyCtrlN,
+	0x0f:   keyCtrlO,
+	0x10:   keyCtrlP,
+	0x11:   keyCtrlC, // Ctrl-Q 同样退出
+	0x14:   keyCtrlT,
+	0x15:   keyCtrlU,
+	0x17:   keyCtrlW,
+	'\x1b': keyEsc,
+}
+
+// csiKeys 转义序列对应的按键，键为 ESC [ 或 ESC O 之后的内容
+var csiKeys = map[string]keyCode{
+	"A":  keyUp,
+	"B":  keyDown,
+	"H":  keyHome,
+	"F":  keyEnd,
+	"Z":  keyBackTab,
+	"1~": keyHome,
+	"7~": keyHome,
+	"4~": keyEnd,
+	"8~": keyEnd,
+	"5~": keyPageUp,
+	"6~": keyPageDown,
+}
+
+// parseKeys 解析终端输入，返回解析出的按键和不完整的剩余字节
+// 终端通常一次发送完整的转义序列，单独的 ESC 视为 Esc 键
+func parseKeys(data []byte) ([]key, []byte) {
+	var keys []key
+	for len(data) > 0 {
+		b := data[0]
+
+		// 方向键等功能键以 ESC [ 或 ESC O 开头，以 0x40-0x7e 之间的字符结尾
+		if b == '\x1b' && len(data) > 1 && (data[1] == '[' || data[1] == 'O') {
+			end := 2
+			for end < len(data) && (data[end] < 0x40 || data[end] > 0x7e) {
+				end++
+			}
+			if end == len(data) {
+				return keys, data
+			}
+			if code, ok := csiKeys[string(data[2:end+1])]; ok {
+				keys = append(keys, key{code: code})
+			}
+			data = data[end+1:]
+			continue
+		}
+
+		if code, ok := controlKeys[b]; ok {
+			keys = append(keys, key{code: code})
+			data = data[1:]
+			continue
+		}
+		if b < 0x20 {
+			data = data[1:]
+			continue
+		}
+
+		// 多字节字符可能被拆分到两次读取中
+		if !utf8.FullRune(data) {
+			return keys, data
+		}
+		r, size := utf8.DecodeRune(data)
+		keys = append(keys, key{code: keyRune, r: r})
+		data = data[size:]
+	}
+	return keys, nil
+}
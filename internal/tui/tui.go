@@ -0,0 +1,337 @@
+// Package tui 实现 gost tui 交互式界面
+// 输入搜索条件时实时更新结果，并在下方预览选中结果所在的文件
+package tui
+
+import (
+	"context"
+	"time"
+	"unicode"
+
+	"github.com/Lingbou/go-search-tools/internal/config"
+)
+
+const (
+	// 输入停止一段时间后才开始搜索，避免每输入一个字符都遍历一次目录
+	searchDelay = 150 * time.Millisecond
+
+	// 搜索过程中刷新界面的间隔，同时用于检测终端大小的变化
+	refreshInterval = 50 * time.Millisecond
+
+	// 结果列表最多保存的结果数，超过后停止搜索
+	maxItems = 10000
+)
+
+// Action 退出界面时对选中结果执行的操作
+type Action int
+
+const (
+	ActionNone  Action = iota // 没有选择结果就退出
+	ActionPrint               // 将选中的结果输出到标准输出
+	ActionEdit                // 使用编辑器打开选中的结果
+)
+
+// Selection 退出界面时选中的结果
+type Selection struct {
+	Action Action
+	Path   string
+	Line   int // 文件名搜索时为 0
+	Column int
+	Text   string
+}
+
+// Options 界面的初始状态
+type Options struct {
+	Mode  string // 初始的搜索方式
+	Query string // 初始的搜索条件
+	Color bool   // 是否使用颜色，不使用颜色时选中的结果仍然以反色显示
+}
+
+// ui 界面状态，只在 Run 的事件循环中访问
+type ui struct {
+	cfg   *config.SearchConfig
+	term  *terminal
+	color bool
+
+	// 输入框的内容和当前的搜索条件
+	text  []rune
+	query query
+
+	// 命令行是否指定了可以切换的过滤条件
+	hasFilters bool
+
+	// 当前搜索的编号、取消函数和状态
+	gen       int
+	cancel    context.CancelFunc
+	searching bool
+	truncated bool
+	elapsed   time.Duration
+	err       error
+
+	// 结果列表、选中的结果和列表的滚动位置
+	items      []item
+	selected   int
+	offset     int
+	listHeight int
+
+	preview previewCache
+}
+
+// Run 启动交互式界面，直到用户选择结果或退出
+// cfg 中的过滤条件、工作协程数和超时时间用于每次搜索
+func Run(cfg *config.SearchConfig, opts Options) (*Selection, error) {
+	term, err := openTerminal()
+	if err != nil {
+		return nil, err
+	}
+	if err := term.start(); err != nil {
+		return nil, err
+	}
+	defer term.stop()
+
+	u := &ui{
+		cfg:        searchConfig(cfg),
+		term:       term,
+		color:      opts.Color,
+		text:       []rune(opts.Query),
+		hasFilters: len(cfg.ExcludeDirs) > 0 || len(cfg.IncludeExts) > 0 || len(cfg.ExcludeExts) > 0,
+	}
+	defer u.stop()
+
+	keys := make(chan []key)
+	go term.readKeys(keys)
+	events := make(chan event)
+
+	// 有初始搜索条件时立即搜索
+	delay := time.NewTimer(0)
+	u.update(query{
+		mode:       opts.Mode,
+		text:       opts.Query,
+		ignoreCase: cfg.IgnoreCase,
+		filters:    true,
+	})
+
+	refresh := time.NewTicker(refreshInterval)
+	defer refresh.Stop()
+	width, height := term.size()
+	dirty, changed := true, false
+
+	for {
+		if dirty {
+			u.render()
+			dirty = false
+		}
+
+		select {
+		case pressed, ok := <-keys:
+			if !ok {
+				return &Selection{}, nil
+			}
+			for _, k := range pressed {
+				if sel := u.handleKey(k, delay); sel != nil {
+					return sel, nil
+				}
+			}
+			dirty = true
+
+		case <-delay.C:
+			u.start(events)
+			dirty = true
+
+		case ev := <-events:
+			if ev.gen == u.gen {
+				u.handleEvent(ev)
+				changed = true
+			}
+
+		case <-refresh.C:
+			// 搜索结果按固定间隔刷新，终端大小变化时也需要重新绘制
+			w, h := term.size()
+			dirty = changed || w != width || h != height
+			width, height = w, h
+			changed = false
+		}
+	}
+}
+
+// searchConfig 返回界面中搜索使用的配置
+// 结果由界面显示，不需要进度、统计信息、报告和错误信息等终端输出
+func searchConfig(base *config.SearchConfig) *config.SearchConfig {
+	cfg := *base
+	cfg.Quiet = false
+	cfg.NoMessages = true
+	cfg.Progress = ""
+	cfg.ShowProgress = false
+	cfg.ShowStats = false
+	cfg.ReportPath = ""
+	cfg.BeforeContext = 0
+	cfg.AfterContext = 0
+	cfg.ReplaceEnabled = false
+	return &cfg
+}
+
+// handleKey 处理一次按键，选择结果或退出时返回 Selection
+func (u *ui) handleKey(k key, delay *time.Timer) *Selection {
+	next := u.query
+	switch k.code {
+	case keyEsc, keyCtrlC:
+		return &Selection{}
+	case keyEnter:
+		return u.selection(ActionPrint)
+	case keyCtrlO:
+		return u.selection(ActionEdit)
+
+	// 移动选中的结果
+	case keyUp, keyCtrlP:
+		u.move(-1)
+	case keyDown, keyCtrlN:
+		u.move(1)
+	case keyPageUp:
+		u.move(-u.listHeight)
+	case keyPageDown:
+		u.move(u.listHeight)
+	case keyHome:
+		u.move(-len(u.items))
+	case keyEnd:
+		u.move(len(u.items))
+
+	// 切换搜索方式和选项
+	case keyTab:
+		next.mode = nextMode(next.mode, 1)
+	case keyBackTab:
+		next.mode = nextMode(next.mode, -1)
+	case keyCtrlT:
+		next.ignoreCase = !next.ignoreCase
+	case keyCtrlF:
+		next.filters = !next.filters
+
+	// 编辑输入框
+	case keyBackspace:
+		if len(u.text) > 0 {
+			u.text = u.text[:len(u.text)-1]
+		}
+	case keyCtrlU:
+		u.text = nil
+	case keyCtrlW:
+		u.text = deleteWord(u.text)
+	case keyRune:
+		u.text = append(u.text, k.r)
+	}
+
+	// 搜索条件变化后重新搜索
+	next.text = string(u.text)
+	if u.update(next) {
+		delay.Reset(searchDelay)
+	}
+	return nil
+}
+
+// update 更新搜索条件，条件变化时取消当前的搜索并清空结果
+func (u *ui) update(next query) bool {
+	if next == u.query && u.gen > 0 {
+		return false
+	}
+	u.query = next
+
+	u.stop()
+	u.gen++
+	u.items = nil
+	u.selected = 0
+	u.offset = 0
+	u.truncated = false
+	u.err = u.query.validate()
+	return true
+}
+
+// start 开始按当前条件搜索
+func (u *ui) start(events chan<- event) {
+	if u.query.text == "" || u.err != nil || u.searching {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	u.cancel = cancel
+	u.searching = true
+	go u.query.run(ctx, u.cfg, u.gen, events)
+}
+
+// stop 取消当前的搜索
+func (u *ui) stop() {
+	if u.cancel != nil {
+		u.cancel()
+		u.cancel = nil
+	}
+	u.searching = false
+}
+
+// handleEvent 处理当前搜索发送的结果
+func (u *ui) handleEvent(ev event) {
+	if len(u.items) < maxItems {
+		u.items = append(u.items, ev.items...)
+		if len(u.items) >= maxItems {
+			u.items = u.items[:maxItems]
+			u.truncated = true
+			u.stop()
+		}
+	}
+	if ev.summary != nil {
+		u.elapsed = ev.summary.Elapsed
+	}
+	if ev.done {
+		u.searching = false
+		if ev.err != nil && !u.truncated {
+			u.err = ev.err
+		}
+	}
+}
+
+// move 移动选中的结果
+func (u *ui) move(delta int) {
+	u.selected = max(0, min(u.selected+delta, len(u.items)-1))
+}
+
+// scrollTo 滚动结果列表，使选中的结果可见
+func (u *ui) scrollTo() {
+	if u.selected < u.offset {
+		u.offset = u.selected
+	}
+	if u.selected >= u.offset+u.listHeight {
+		u.offset = u.selected - u.listHeight + 1
+	}
+}
+
+// selection 返回选中的结果，没有结果时不退出
+func (u *ui) selection(action Action) *Selection {
+	if u.selected >= len(u.items) {
+		return nil
+	}
+	it := u.items[u.selected]
+	return &Selection{
+		Action: action,
+		Path:   it.Path,
+		Line:   it.Line,
+		Column: it.Column,
+		Text:   it.Text,
+	}
+}
+
+// nextMode 返回按 Tab 或 Shift-Tab 切换后的搜索方式
+func nextMode(mode string, step int) string {
+	for i, m := range modes {
+		if m == mode {
+			return modes[(i+step+len(modes))%len(modes)]
+		}
+	}
+	return modes[0]
+}
+
+// deleteWord 删除输入框末尾的一个单词，与终端中的 Ctrl-W 相同
+func deleteWord(text []rune) []rune {
+	i := len(text)
+	for i > 0 && unicode.IsSpace(text[i-1]) {
+		i--
+	}
+	for i > 0 && !unicode.IsSpace(text[i-1]) {
+		i--
+	}
+	return text[:i]
+}
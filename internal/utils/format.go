@@ -3,9 +3,14 @@ package utils
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 	// "time"
 
 	"github.com/fatih/color"
+
+	"github.com/Lingbou/go-search-tools/internal/i18n"
+	"github.com/Lingbou/go-search-tools/internal/matcher"
 )
 
 // FormatSize 格式化文件大小
@@ -23,14 +28,24 @@ func FormatSize(bytes int64) string {
 }
 
 // PrintMatch 打印匹配结果
-func PrintMatch(path string, info os.FileInfo, useColor bool) {
+// showMeta 为 false 时只打印路径，不打印类型、大小和修改时间
+func PrintMatch(path string, info os.FileInfo, useColor, showMeta bool) {
+	if !showMeta {
+		if useColor {
+			fmt.Println(color.MagentaString(path))
+		} else {
+			fmt.Println(path)
+		}
+		return
+	}
+	
 	if useColor {
 		// 使用颜色区分不同部分
 		fileType := ""
 		if info.IsDir() {
-			fileType = color.CyanString("[目录]")
+			fileType = color.CyanString(i18n.T("output.dir"))
 		} else {
-			fileType = color.GreenString("[文件]")
+			fileType = color.GreenString(i18n.T("output.file"))
 		}
 		
 		size := ""
@@ -42,14 +57,135 @@ func PrintMatch(path string, info os.FileInfo, useColor bool) {
 		
 		fmt.Printf("%s %s %s %s\n", 
 			fileType, 
-			color.MagentaString(info.Name()), 
+			color.MagentaString(path), 
 			color.BlueString(size), 
 			color.YellowString(modified))
 	} else {
 		fmt.Printf("%s %s %d %s\n", 
 			info.Mode(), 
-			info.Name(), 
+			path, 
 			info.Size(), 
 			info.ModTime().Format("2006-01-02 15:04:05"))
 	}
-}
\ No newline at end of file
+}
+
+// PrintLineMatch 以 path:line:col: text 的格式打印一行匹配结果
+// pathEnd 为路径之后的分隔符，通常为 ":"，使用 NUL 分隔时为 "\x00"
+// path 为空时只打印 line:col: text，用于按文件分组输出
+func PrintLineMatch(path, pathEnd string, m matcher.Match, useColor bool) {
+	if useColor {
+		fmt.Printf("%s%s:%d: %s\n",
+			colorPath(path, pathEnd),
+			color.GreenString("%d", m.Line),
+			m.Column,
+			HighlightSpans(m.Text, m.Spans))
+	} else {
+		fmt.Printf("%s%s%d:%d: %s\n", path, pathEnd, m.Line, m.Column, m.Text)
+	}
+}
+
+// PrintContextLine 以 path-line- text 的格式打印一行上下文
+// pathEnd 为路径之后的分隔符，通常为 "-"，使用 NUL 分隔时为 "\x00"
+// path 为空时只打印 line- text，用于按文件分组输出
+func PrintContextLine(path, pathEnd string, lineNum int, text string, useColor bool) {
+	if useColor {
+		fmt.Printf("%s%s- %s\n",
+			colorPath(path, pathEnd),
+			color.CyanString("%d", lineNum),
+			color.HiBlackString(text))
+	} else {
+		fmt.Printf("%s%s%d- %s\n", path, pathEnd, lineNum, text)
+	}
+}
+
+// PrintHeading 按文件分组输出时打印文件路径标题
+func PrintHeading(path string, useColor bool) {
+	if useColor {
+		fmt.Println(color.New(color.FgMagenta, color.Bold).Sprint(path))
+	} else {
+		fmt.Println(path)
+	}
+}
+
+// colorPath 为路径着色并加上分隔符，路径为空时返回空字符串
+func colorPath(path, pathEnd string) string {
+	if path == "" {
+		return pathEnd
+	}
+	return color.MagentaString(path) + pathEnd
+}
+
+// PrintCount 以 path:count 的格式打印一个文件的匹配数
+func PrintCount(path, pathEnd string, count int, useColor bool) {
+	if useColor {
+		fmt.Printf("%s%s%d\n", color.MagentaString(path), pathEnd, count)
+	} else {
+		fmt.Printf("%s%s%d\n", path, pathEnd, count)
+	}
+}
+
+// Hyperlink 使用 OSC 8 转义序列将文本包装为终端超链接
+func Hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// PrintError 以红色向标准错误打印一条诊断信息，避免与标准输出中的结果混在一起
+func PrintError(format string, a ...interface{}) {
+	color.New(color.FgRed).Fprintf(os.Stderr, format+"\n", a...)
+}
+
+// PrintSeparator 打印上下文分组之间的分隔符
+func PrintSeparator(useColor bool) {
+	if useColor {
+		fmt.Println(color.HiBlackString("--"))
+	} else {
+		fmt.Println("--")
+	}
+}
+
+// 匹配文本的高亮样式
+var highlightColor = color.New(color.FgRed, color.Bold)
+
+// HighlightSpans 高亮一行中的所有匹配区间
+// 重叠的区间会被合并，零宽度的区间不做标记
+func HighlightSpans(text string, spans []matcher.Span) string {
+	merged := MergeSpans(spans)
+	if len(merged) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, span := range merged {
+		b.WriteString(text[last:span.Start])
+		b.WriteString(highlightColor.Sprint(text[span.Start:span.End]))
+		last = span.End
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// MergeSpans 按起始位置排序并合并重叠的非空区间
+func MergeSpans(spans []matcher.Span) []matcher.Span {
+	var sorted []matcher.Span
+	for _, span := range spans {
+		if span.End > span.Start {
+			sorted = append(sorted, span)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+
+	var merged []matcher.Span
+	for _, span := range sorted {
+		if n := len(merged); n > 0 && span.Start <= merged[n-1].End {
+			if span.End > merged[n-1].End {
+				merged[n-1].End = span.End
+			}
+			continue
+		}
+		merged = append(merged, span)
+	}
+	return merged
+}
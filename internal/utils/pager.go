@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// StartPager 启动 $PAGER 指定的分页程序（默认为 less），并将标准输出重定向到分页程序
+// 返回的函数关闭输出并等待分页程序退出，之后恢复原来的标准输出
+// 分页程序为 less 且未设置 LESS 环境变量时使用 LESS=FRX：
+// 内容不足一屏时直接输出并退出，并且原样显示颜色转义序列
+func StartPager() (func(), error) {
+	args := strings.Fields(os.Getenv("PAGER"))
+	if len(args) == 0 {
+		args = []string{"less"}
+	}
+	if len(args) == 1 && args[0] == "cat" {
+		return func() {}, nil
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	if name := strings.TrimSuffix(filepath.Base(args[0]), ".exe"); name == "less" {
+		less, ok := os.LookupEnv("LESS")
+		switch {
+		case !ok:
+			cmd.Env = append(os.Environ(), "LESS=FRX")
+		case !strings.Contains(less, "R") && !strings.Contains(strings.Join(args[1:], " "), "R"):
+			cmd.Args = append(cmd.Args, "-R")
+		}
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stdin = r
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		r.Close()
+		w.Close()
+		return nil, err
+	}
+	r.Close()
+
+	stdout, output := os.Stdout, color.Output
+	os.Stdout = w
+	color.Output = w
+
+	return func() {
+		w.Close()
+		cmd.Wait()
+		os.Stdout = stdout
+		color.Output = output
+	}, nil
+}
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 
 	"github.com/schollz/progressbar/v3"
+	
+	"github.com/Lingbou/go-search-tools/internal/i18n"
 )
 
 // ProgressTracker 进度跟踪器
@@ -12,6 +14,9 @@ type ProgressTracker struct {
 	Bar        *progressbar.ProgressBar
 	TotalFiles int
 	Enabled    bool
+	
+	// 以 JSON 事件输出进度时使用，此时不显示进度条
+	json *jsonProgress
 }
 
 // NewProgressTracker 创建一个新的进度跟踪器
@@ -35,12 +40,16 @@ func (p *ProgressTracker) SetTotal(total int) {
 	}
 	
 	p.TotalFiles = total
-	p.Bar = progressbar.Default(int64(total), "搜索中")
+	if p.json != nil {
+		p.json.total.Store(int64(total))
+		return
+	}
+	p.Bar = progressbar.Default(int64(total), i18n.T("search.progress"))
 }
 
 // Increment 增加进度
 func (p *ProgressTracker) Increment() {
-	if !p.Enabled {
+	if !p.Enabled || p.json != nil {
 		return
 	}
 	
@@ -0,0 +1,171 @@
+package utils
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// progressInterval JSON 进度事件的输出间隔
+const progressInterval = 250 * time.Millisecond
+
+// ProgressEvent 一条 JSON 进度事件，供包装 gost 的程序显示自己的进度
+type ProgressEvent struct {
+	Type          string `json:"type"`                  // 总是 "progress"
+	FilesWalked   int64  `json:"files_walked"`          // 遍历到并通过过滤的文件数
+	FilesSearched int64  `json:"files_searched"`        // 已搜索的文件数
+	FilesTotal    int64  `json:"files_total,omitempty"` // 预先统计的文件总数
+	Bytes         int64  `json:"bytes"`                 // 已读取的字节数
+	Matches       int64  `json:"matches"`               // 已找到的匹配行数，文件名搜索时为匹配的文件数
+	CurrentDir    string `json:"current_dir"`           // 正在遍历的目录
+	ElapsedMs     int64  `json:"elapsed_ms"`            // 已用时间
+	ETAMs         *int64 `json:"eta_ms,omitempty"`      // 预计剩余时间，无法估计时省略
+	Done          bool   `json:"done"`                  // 是否为搜索结束时的最后一条事件
+}
+
+// jsonProgress 在后台定期输出 JSON 进度事件，计数可以在多个工作协程中更新
+type jsonProgress struct {
+	encoder *json.Encoder
+	start   time.Time
+
+	total    atomic.Int64
+	walked   atomic.Int64
+	searched atomic.Int64
+	bytes    atomic.Int64
+	matches  atomic.Int64
+	dir      atomic.Value
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// NewJSONProgressTracker 创建一个以 JSON Lines 格式向 w 输出进度事件的进度跟踪器
+// 搜索结束时需要调用 Finish 输出最后一条事件
+func NewJSONProgressTracker(w io.Writer) *ProgressTracker {
+	p := &jsonProgress{
+		encoder: json.NewEncoder(w),
+		start:   time.Now(),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	p.dir.Store("")
+	go p.run()
+
+	return &ProgressTracker{
+		Enabled: true,
+		json:    p,
+	}
+}
+
+// progressFiles 已打开的进度事件输出，同一个文件描述符只创建一个 *os.File，
+// 否则被回收的 *os.File 会关闭仍在使用的文件描述符
+var (
+	progressFilesMu sync.Mutex
+	progressFiles   = map[int]*os.File{}
+)
+
+// ProgressOutput 返回文件描述符对应的进度事件输出，2 为标准错误
+func ProgressOutput(fd int) *os.File {
+	if fd == 2 {
+		return os.Stderr
+	}
+
+	progressFilesMu.Lock()
+	defer progressFilesMu.Unlock()
+	f, ok := progressFiles[fd]
+	if !ok {
+		f = os.NewFile(uintptr(fd), "progress")
+		progressFiles[fd] = f
+	}
+	return f
+}
+
+// CheckProgressOutput 检查文件描述符是否已打开，未打开时进度事件会被全部丢弃
+func CheckProgressOutput(fd int) error {
+	f := ProgressOutput(fd)
+	if f == nil {
+		return os.ErrInvalid
+	}
+	_, err := f.Stat()
+	return err
+}
+
+// run 定期输出进度事件，直到 Finish 被调用
+func (p *jsonProgress) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.emit(false)
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// emit 输出一条进度事件
+func (p *jsonProgress) emit(done bool) {
+	elapsed := time.Since(p.start)
+	event := ProgressEvent{
+		Type:          "progress",
+		FilesWalked:   p.walked.Load(),
+		FilesSearched: p.searched.Load(),
+		FilesTotal:    p.total.Load(),
+		Bytes:         p.bytes.Load(),
+		Matches:       p.matches.Load(),
+		CurrentDir:    p.dir.Load().(string),
+		ElapsedMs:     elapsed.Milliseconds(),
+		Done:          done,
+	}
+
+	// 按已搜索文件的平均耗时估计剩余时间
+	if event.FilesTotal > 0 && event.FilesSearched > 0 && !done {
+		remaining := max(event.FilesTotal-event.FilesSearched, 0)
+		eta := elapsed.Milliseconds() * remaining / event.FilesSearched
+		event.ETAMs = &eta
+	}
+
+	p.encoder.Encode(event)
+}
+
+// Walked 记录遍历到的目录或文件，目录会作为当前目录输出
+func (p *ProgressTracker) Walked(path string, info os.FileInfo) {
+	if p.json == nil {
+		return
+	}
+	if info.IsDir() {
+		p.json.dir.Store(path)
+	} else {
+		p.json.walked.Add(1)
+	}
+}
+
+// Searched 记录搜索完成的一个文件，以及读取的字节数和找到的匹配数
+func (p *ProgressTracker) Searched(bytes int64, matches int) {
+	if p.json == nil {
+		return
+	}
+	p.json.searched.Add(1)
+	p.json.bytes.Add(bytes)
+	p.json.matches.Add(int64(matches))
+}
+
+// Finish 停止输出进度事件，并输出一条 done 为 true 的最后事件
+// 可以多次调用，只有第一次生效
+func (p *ProgressTracker) Finish() {
+	if p.json == nil {
+		return
+	}
+	p.json.once.Do(func() {
+		close(p.json.stop)
+		<-p.json.done
+		p.json.emit(true)
+	})
+}
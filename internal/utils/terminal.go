@@ -0,0 +1,13 @@
+package utils
+
+import (
+	"os"
+
+	"github.com/mattn/go-isatty"
+)
+
+// IsTerminal 判断文件是否连接到终端，输出被重定向到文件或管道时返回 false
+func IsTerminal(f *os.File) bool {
+	fd := f.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}